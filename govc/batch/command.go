@@ -0,0 +1,289 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batch
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type batch struct {
+	*flags.ClientFlag
+	*flags.OutputFlag
+
+	parallel int
+}
+
+func init() {
+	cli.Register("batch", &batch{})
+}
+
+func (cmd *batch) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.IntVar(&cmd.parallel, "parallel", 1, "Number of commands to run in parallel")
+}
+
+func (cmd *batch) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *batch) Usage() string {
+	return "[FILE]"
+}
+
+func (cmd *batch) Description() string {
+	return `Run govc commands read from FILE or stdin, one per line, using a single session.
+
+Arguments are split as a shell would, with support for single and double quotes and backslash escapes.
+Empty lines and lines starting with '#' are ignored, the leading "govc" of a line is optional.
+All commands use the session of the batch command, connection flags such as -u given in FILE are ignored.
+
+The exit status of each line is reported once all commands have completed, and the batch
+fails if any of the commands failed.  Output of commands run with -parallel may be interleaved.
+When structured output is requested, such as with -json, the output of each command is written
+to stderr, such that stdout contains only the batch result.  Messages that a command prints
+without its output flags, rather than as its result, are not redirected.
+
+Examples:
+  govc batch commands.txt
+  printf "vm.power -on vm1\nvm.power -on vm2\n" | govc batch -parallel 2
+  govc batch -json commands.txt 2>/dev/null | jq '.[] | select(.Status != 0)'`
+}
+
+type status struct {
+	Line    int
+	Command string
+	Status  int
+	Error   string `json:",omitempty"`
+}
+
+type line struct {
+	n    int
+	text string
+	args []string
+}
+
+func readLines(r io.Reader) ([]line, error) {
+	var lines []line
+
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		args, err := splitArgs(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+
+		if len(args) != 0 && args[0] == "govc" {
+			args = args[1:]
+		}
+
+		lines = append(lines, line{n, text, args})
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitArgs splits s into arguments, supporting single and double quotes and backslash escapes.
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var arg []rune
+	var quote rune
+	inArg := false
+	escape := false
+
+	for _, c := range s {
+		switch {
+		case escape:
+			arg = append(arg, c)
+			escape = false
+		case c == '\\' && quote != '\'':
+			escape = true
+			inArg = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				arg = append(arg, c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t':
+			if inArg {
+				args = append(args, string(arg))
+				arg = arg[:0]
+				inArg = false
+			}
+		default:
+			arg = append(arg, c)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+
+	if escape {
+		return nil, errors.New("trailing backslash")
+	}
+
+	if inArg {
+		args = append(args, string(arg))
+	}
+
+	return args, nil
+}
+
+func execute(ctx context.Context, args []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	err = cli.Execute(ctx, args)
+	if err == flag.ErrHelp {
+		err = fmt.Errorf("invalid usage, see 'govc %s -h'", args[0])
+	}
+
+	return err
+}
+
+func (cmd *batch) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() > 1 {
+		return flag.ErrHelp
+	}
+
+	if cmd.parallel < 1 {
+		return errors.New("-parallel must be at least 1")
+	}
+
+	var r io.Reader = os.Stdin
+	if f.NArg() == 1 && f.Arg(0) != "-" {
+		file, err := os.Open(f.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	lines, err := readLines(r)
+	if err != nil {
+		return err
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	ctx = flags.ContextWithClient(ctx, c)
+
+	if cmd.JSON {
+		// Keep stdout for the batch result when structured output is requested
+		ctx = flags.ContextWithOutput(ctx, os.Stderr)
+	}
+
+	res := make(batchResult, len(lines))
+	work := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < cmd.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range work {
+				l := lines[i]
+				res[i] = status{Line: l.n, Command: l.text}
+
+				if err := execute(ctx, l.args); err != nil {
+					res[i].Status = 1
+					res[i].Error = err.Error()
+					fmt.Fprintf(os.Stderr, "%s: line %d: %s\n", os.Args[0], l.n, err)
+				}
+			}
+		}()
+	}
+
+	for i := range lines {
+		work <- i
+	}
+	close(work)
+
+	wg.Wait()
+
+	if err = cmd.WriteResult(res); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, s := range res {
+		if s.Status != 0 {
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d commands failed", failed, len(res))
+	}
+
+	return nil
+}
+
+type batchResult []status
+
+func (r batchResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, s := range r {
+		fmt.Fprintf(tw, "%d\t%d\t%s\n", s.Line, s.Status, s.Command)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line   string
+		expect []string
+	}{
+		{"vm.power -on vm1", []string{"vm.power", "-on", "vm1"}},
+		{`ls  "/dc1/vm/my vm"`, []string{"ls", "/dc1/vm/my vm"}},
+		{`guest.run -vm vm1 sh -c 'echo "$HOME"'`, []string{"guest.run", "-vm", "vm1", "sh", "-c", `echo "$HOME"`}},
+		{`vm.info my\ vm ""`, []string{"vm.info", "my vm", ""}},
+	}
+
+	for _, test := range tests {
+		args, err := splitArgs(test.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(args, test.expect) {
+			t.Errorf("%s: %#v", test.line, args)
+		}
+	}
+
+	for _, line := range []string{`ls "/dc1`, `ls \`} {
+		if _, err := splitArgs(line); err == nil {
+			t.Errorf("%s: expected error", line)
+		}
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	input := "# comment\n\ngovc ls /\n  vm.info vm1\n"
+
+	lines, err := readLines(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("lines=%d", len(lines))
+	}
+
+	if lines[0].n != 3 || !reflect.DeepEqual(lines[0].args, []string{"ls", "/"}) {
+		t.Errorf("%#v", lines[0])
+	}
+
+	if lines[1].n != 4 || lines[1].text != "vm.info vm1" {
+		t.Errorf("%#v", lines[1])
+	}
+}
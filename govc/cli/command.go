@@ -17,10 +17,12 @@ limitations under the License.
 package cli
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"sort"
 	"text/tabwriter"
 
@@ -41,11 +43,28 @@ type Command interface {
 	Run(ctx context.Context, f *flag.FlagSet) error
 }
 
+// FlagParser is implemented by commands that accept arguments the flag package cannot parse,
+// such as flags that are not known until the command is run.
+type FlagParser interface {
+	Parse(f *flag.FlagSet, args []string) error
+}
+
+func parse(cmd Command, f *flag.FlagSet, args []string) error {
+	if p, ok := cmd.(FlagParser); ok {
+		return p.Parse(f, args)
+	}
+
+	return f.Parse(args)
+}
+
 func generalHelp() {
 	fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
 
 	cmds := []string{}
 	for name := range commands {
+		if hidden[name] {
+			continue
+		}
 		cmds = append(cmds, name)
 	}
 
@@ -128,7 +147,7 @@ func Run(args []string) int {
 	ctx := context.Background()
 	cmd.Register(ctx, fs)
 
-	if err = fs.Parse(args[1:]); err != nil {
+	if err = parse(cmd, fs, args[1:]); err != nil {
 		goto error
 	}
 
@@ -157,3 +176,42 @@ error:
 
 	return 1
 }
+
+// Execute runs the command given by args using ctx, without logging out.
+// A new instance of the command is used, such that commands can be executed concurrently.
+// Used by commands that run other commands, such as batch.
+func Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("no command given")
+	}
+
+	name, ok := aliases[args[0]]
+	if !ok {
+		name = args[0]
+	}
+
+	registered, ok := commands[name]
+	if !ok || hidden[name] {
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+
+	// Shallow copy of the registered command, which has not been used for Register or Run.
+	v := reflect.New(reflect.TypeOf(registered).Elem())
+	v.Elem().Set(reflect.ValueOf(registered).Elem())
+	cmd := v.Interface().(Command)
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	cmd.Register(ctx, fs)
+
+	if err := parse(cmd, fs, args[1:]); err != nil {
+		return err
+	}
+
+	if err := cmd.Process(ctx); err != nil {
+		return err
+	}
+
+	return cmd.Run(ctx, fs)
+}
@@ -20,8 +20,16 @@ var commands = map[string]Command{}
 
 var aliases = map[string]string{}
 
-func Register(name string, c Command) {
+var hidden = map[string]bool{}
+
+// Register adds the command c with the given name.  If hide is true, the command is not
+// listed by the general help or shell completion, useful for helper commands.
+func Register(name string, c Command, hide ...bool) {
 	commands[name] = c
+
+	if len(hide) != 0 && hide[0] {
+		hidden[name] = true
+	}
 }
 
 func Alias(name string, alias string) {
@@ -31,3 +39,8 @@ func Alias(name string, alias string) {
 func Commands() map[string]Command {
 	return commands
 }
+
+// Hidden returns true if the command with the given name was registered as hidden.
+func Hidden(name string) bool {
+	return hidden[name]
+}
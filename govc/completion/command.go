@@ -0,0 +1,221 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type completion struct {
+	*flags.EmptyFlag
+}
+
+func init() {
+	cli.Register("completion", &completion{})
+}
+
+func (cmd *completion) Usage() string {
+	return "bash|zsh|fish"
+}
+
+func (cmd *completion) Description() string {
+	return `Output shell completion script for govc commands, flags and inventory paths.
+
+Inventory paths for command arguments and flags such as -vm, -ds, -net, -pool and -host
+are completed by querying the inventory, using the connection settings of the command line,
+GOVC_* environment variables and current context.
+
+Examples:
+  source <(govc completion bash)
+  govc completion zsh > "${fpath[1]}/_govc"
+  govc completion fish > ~/.config/fish/completions/govc.fish`
+}
+
+type commandFlags struct {
+	name  string
+	flags []*flag.Flag
+}
+
+// commandList returns the visible commands and their flags, sorted by name.
+func commandList() []commandFlags {
+	var cmds []commandFlags
+
+	for name, cmd := range cli.Commands() {
+		if cli.Hidden(name) || name == "completion" {
+			continue
+		}
+
+		fs := flag.NewFlagSet(name, flag.ContinueOnError)
+		cmd.Register(context.Background(), fs)
+
+		c := commandFlags{name: name}
+		fs.VisitAll(func(f *flag.Flag) {
+			c.flags = append(c.flags, f)
+		})
+
+		cmds = append(cmds, c)
+	}
+
+	sort.Sort(byName(cmds))
+
+	return cmds
+}
+
+type byName []commandFlags
+
+func (s byName) Len() int           { return len(s) }
+func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byName) Less(i, j int) bool { return s[i].name < s[j].name }
+
+func pathFlags() []string {
+	var names []string
+	for name := range pathTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cmd *completion) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	var buf bytes.Buffer
+
+	switch f.Arg(0) {
+	case "bash":
+		bash(&buf, commandList())
+	case "zsh":
+		fmt.Fprintln(&buf, "#compdef govc")
+		fmt.Fprintln(&buf, "autoload -U +X bashcompinit && bashcompinit")
+		bash(&buf, commandList())
+	case "fish":
+		fish(&buf, commandList())
+	default:
+		return fmt.Errorf("unsupported shell: %s", f.Arg(0))
+	}
+
+	_, err := buf.WriteTo(os.Stdout)
+	return err
+}
+
+func bash(buf *bytes.Buffer, cmds []commandFlags) {
+	var names []string
+	for _, c := range cmds {
+		names = append(names, c.name)
+	}
+
+	fmt.Fprintf(buf, "_govc_commands=%q\n\n", strings.Join(names, " "))
+
+	fmt.Fprintln(buf, "declare -A _govc_flags")
+	for _, c := range cmds {
+		var fnames []string
+		for _, f := range c.flags {
+			fnames = append(fnames, "-"+f.Name)
+		}
+		fmt.Fprintf(buf, "_govc_flags[%s]=%q\n", c.name, strings.Join(fnames, " "))
+	}
+
+	fmt.Fprintf(buf, bashFunc, "-"+strings.Join(pathFlags(), "|-"))
+}
+
+const bashFunc = `
+_govc_complete_path() {
+  local args=() i
+  # Pass through connection flags given on the command line
+  for ((i = 2; i < COMP_CWORD; i++)); do
+    case "${COMP_WORDS[i]}" in
+      -u|-dc|-credential-helper|-thumbprint)
+        args+=("${COMP_WORDS[i]}" "${COMP_WORDS[i+1]}")
+        ;;
+    esac
+  done
+
+  COMPREPLY=($(govc completion.path "${args[@]}" "$@" -- "$cur" 2>/dev/null))
+
+  if [[ "${COMPREPLY[*]}" == */* ]]; then
+    type compopt &>/dev/null && compopt -o nospace
+  fi
+}
+
+_govc() {
+  local cur=${COMP_WORDS[COMP_CWORD]}
+  local prev=${COMP_WORDS[COMP_CWORD-1]}
+
+  if [ $COMP_CWORD -eq 1 ]; then
+    COMPREPLY=($(compgen -W "$_govc_commands" -- "$cur"))
+    return
+  fi
+
+  case "$prev" in
+    %s)
+      _govc_complete_path -type "${prev#-}"
+      return
+      ;;
+  esac
+
+  if [[ "$cur" == -* ]]; then
+    COMPREPLY=($(compgen -W "${_govc_flags[${COMP_WORDS[1]}]}" -- "$cur"))
+    return
+  fi
+
+  _govc_complete_path
+}
+
+complete -F _govc govc
+`
+
+func fish(buf *bytes.Buffer, cmds []commandFlags) {
+	quote := func(s string) string {
+		return "'" + strings.Replace(strings.Replace(s, `\`, `\\`, -1), "'", `\'`, -1) + "'"
+	}
+
+	fmt.Fprintln(buf, "complete -c govc -f")
+
+	for _, c := range cmds {
+		fmt.Fprintf(buf, "complete -c govc -n __fish_use_subcommand -a %s\n", c.name)
+	}
+
+	paths := make(map[string]bool)
+	for _, name := range pathFlags() {
+		paths[name] = true
+	}
+
+	for _, c := range cmds {
+		cond := quote("__fish_seen_subcommand_from " + c.name)
+
+		for _, f := range c.flags {
+			fmt.Fprintf(buf, "complete -c govc -n %s -o %s -d %s", cond, f.Name, quote(f.Usage))
+			if paths[f.Name] {
+				fmt.Fprintf(buf, " -x -a %s", quote("(govc completion.path -type "+f.Name+" -- (commandline -ct) 2>/dev/null)"))
+			}
+			fmt.Fprintln(buf)
+		}
+	}
+
+	fmt.Fprintln(buf, "complete -c govc -n 'not __fish_use_subcommand' -a '(govc completion.path -- (commandline -ct) 2>/dev/null)'")
+}
@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+// pathTypes maps the flags that take an inventory path to the folder that relative paths are resolved in
+// and the object types that are completed.  Containers are always completed, to allow descending into them.
+var pathTypes = map[string]struct {
+	folder string
+	types  []string
+}{
+	"vm":     {"vm", []string{"VirtualMachine"}},
+	"ds":     {"datastore", []string{"Datastore"}},
+	"net":    {"network", []string{"Network", "DistributedVirtualPortgroup", "OpaqueNetwork"}},
+	"host":   {"host", []string{"HostSystem"}},
+	"pool":   {"host", []string{"ResourcePool"}},
+	"folder": {"", []string{"Folder"}},
+	"dc":     {"/", []string{"Datacenter"}},
+}
+
+var containers = map[string]bool{
+	"Folder":                 true,
+	"Datacenter":             true,
+	"ComputeResource":        true,
+	"ClusterComputeResource": true,
+	"ResourcePool":           true,
+	"VirtualApp":             true,
+	"StoragePod":             true,
+}
+
+type complete struct {
+	*flags.DatacenterFlag
+
+	kind string
+}
+
+func init() {
+	cli.Register("completion.path", &complete{}, true)
+}
+
+func (cmd *complete) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.kind, "type", "", "Flag name of the path to complete (vm, ds, net, host, pool, folder, dc)")
+}
+
+func (cmd *complete) Process(ctx context.Context) error {
+	if err := cmd.DatacenterFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *complete) Usage() string {
+	return "PARTIAL"
+}
+
+func (cmd *complete) Description() string {
+	return `Complete inventory path PARTIAL, used by the 'govc completion' shell scripts.`
+}
+
+func (cmd *complete) Run(ctx context.Context, f *flag.FlagSet) error {
+	// Errors are not reported, as the output is consumed by shell completion.
+	res, _ := cmd.complete(ctx, f.Arg(0))
+
+	return cmd.WriteResult(res)
+}
+
+func (cmd *complete) complete(ctx context.Context, partial string) (completeResult, error) {
+	pt, ok := pathTypes[cmd.kind]
+	if cmd.kind != "" && !ok {
+		return nil, fmt.Errorf("unsupported type: %s", cmd.kind)
+	}
+
+	finder, err := cmd.Finder()
+	if err != nil {
+		return nil, err
+	}
+
+	// The directory part of the partial path, as typed
+	dir := ""
+	if i := strings.LastIndex(partial, "/"); i >= 0 {
+		dir = partial[:i+1]
+	}
+
+	query := dir
+	if !path.IsAbs(dir) && pt.folder != "" {
+		if pt.folder == "/" {
+			query = "/" + dir
+		} else if _, err = cmd.Datacenter(); err == nil {
+			query = path.Join(pt.folder, dir)
+		}
+	}
+
+	es, err := finder.ManagedObjectListChildren(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var res completeResult
+
+	for _, e := range es {
+		kind := e.Object.Reference().Type
+		name := dir + path.Base(e.Path)
+
+		if !strings.HasPrefix(name, partial) {
+			continue
+		}
+
+		if containers[kind] {
+			res = append(res, name+"/")
+			continue
+		}
+
+		if ok && !typeMatch(pt.types, kind) {
+			continue
+		}
+
+		res = append(res, name)
+	}
+
+	return res, nil
+}
+
+func typeMatch(types []string, kind string) bool {
+	for _, t := range types {
+		if t == kind {
+			return true
+		}
+	}
+	return false
+}
+
+type completeResult []string
+
+func (r completeResult) Write(w io.Writer) error {
+	for _, name := range r {
+		fmt.Fprintln(w, name)
+	}
+	return nil
+}
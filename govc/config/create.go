@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/soap"
+	"golang.org/x/net/context"
+)
+
+type create struct {
+	flags.Context
+
+	force bool
+	use   bool
+}
+
+func init() {
+	cli.Register("context.create", &create{})
+}
+
+func (cmd *create) Register(ctx context.Context, f *flag.FlagSet) {
+	insecure := false
+	switch strings.ToLower(os.Getenv("GOVC_INSECURE")) {
+	case "1", "true":
+		insecure = true
+	}
+
+	f.StringVar(&cmd.URL, "url", os.Getenv("GOVC_URL"), "ESX or vCenter URL [GOVC_URL]")
+	f.StringVar(&cmd.Username, "username", os.Getenv("GOVC_USERNAME"), "Username [GOVC_USERNAME]")
+	f.StringVar(&cmd.CredentialHelper, "credential-helper", os.Getenv("GOVC_CREDENTIAL_HELPER"), "Credential helper command [GOVC_CREDENTIAL_HELPER]")
+	f.BoolVar(&cmd.Insecure, "k", insecure, "Skip verification of server certificate [GOVC_INSECURE]")
+	f.StringVar(&cmd.Thumbprint, "thumbprint", os.Getenv("GOVC_THUMBPRINT"), "SHA1 thumbprint of the server certificate [GOVC_THUMBPRINT]")
+	f.StringVar(&cmd.Datacenter, "dc", os.Getenv("GOVC_DATACENTER"), "Datacenter [GOVC_DATACENTER]")
+	f.StringVar(&cmd.Datastore, "ds", os.Getenv("GOVC_DATASTORE"), "Datastore [GOVC_DATASTORE]")
+	f.StringVar(&cmd.Network, "net", os.Getenv("GOVC_NETWORK"), "Network [GOVC_NETWORK]")
+	f.StringVar(&cmd.ResourcePool, "pool", os.Getenv("GOVC_RESOURCE_POOL"), "Resource pool [GOVC_RESOURCE_POOL]")
+	f.StringVar(&cmd.Folder, "folder", os.Getenv("GOVC_FOLDER"), "Folder [GOVC_FOLDER]")
+	f.StringVar(&cmd.Host, "host", os.Getenv("GOVC_HOST"), "Host system [GOVC_HOST]")
+	f.BoolVar(&cmd.force, "f", false, "Overwrite existing context")
+	f.BoolVar(&cmd.use, "use", false, "Set as the current context")
+}
+
+func (cmd *create) Process(ctx context.Context) error {
+	return nil
+}
+
+func (cmd *create) Usage() string {
+	return "NAME"
+}
+
+func (cmd *create) Description() string {
+	return `Create context NAME in the govc config file.
+
+Settings default to the current GOVC_* environment variables.
+Passwords are never stored, any password in the URL is removed.
+
+Examples:
+  govc context.create -url vc1.example.com -username admin@vsphere.local -dc DC1 -ds datastore1 vc1
+  GOVC_URL=vc2.example.com GOVC_DATACENTER=DC2 govc context.create -use vc2`
+}
+
+func (cmd *create) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	if cmd.URL == "" {
+		return errors.New("specify an ESX or vCenter URL")
+	}
+
+	u, err := soap.ParseURL(cmd.URL)
+	if err != nil {
+		return err
+	}
+
+	if u.User != nil {
+		if cmd.Username == "" {
+			cmd.Username = u.User.Username()
+		}
+		u.User = nil
+	}
+
+	if u.Path == "/sdk" {
+		u.Path = ""
+	}
+
+	cmd.URL = strings.TrimPrefix(u.String(), "https://")
+
+	config, err := flags.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := f.Arg(0)
+
+	if _, exists := config.Contexts[name]; exists && !cmd.force {
+		return errors.New("context " + name + " already exists")
+	}
+
+	c := cmd.Context
+	config.Contexts[name] = &c
+
+	if cmd.use {
+		config.Current = name
+	}
+
+	return config.Save()
+}
+
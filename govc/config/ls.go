@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type ls struct {
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("context.ls", &ls{})
+}
+
+func (cmd *ls) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *ls) Process(ctx context.Context) error {
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *ls) Description() string {
+	return `List contexts defined in the govc config file.
+
+The current context is marked with '*'.
+
+Examples:
+  govc context.ls`
+}
+
+func (cmd *ls) Run(ctx context.Context, f *flag.FlagSet) error {
+	config, err := flags.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	return cmd.WriteResult(&lsResult{config})
+}
+
+type lsResult struct {
+	*flags.Config
+}
+
+func (r *lsResult) Write(w io.Writer) error {
+	current := r.CurrentContextName()
+
+	var names []string
+	for name := range r.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, name := range names {
+		mark := " "
+		if name == current {
+			mark = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", mark, name, r.Contexts[name].URL)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type rm struct {
+	*flags.EmptyFlag
+}
+
+func init() {
+	cli.Register("context.rm", &rm{})
+}
+
+func (cmd *rm) Usage() string {
+	return "NAME..."
+}
+
+func (cmd *rm) Description() string {
+	return `Remove contexts from the govc config file.
+
+Examples:
+  govc context.rm lab`
+}
+
+func (cmd *rm) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	config, err := flags.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range f.Args() {
+		if _, err = config.Context(name); err != nil {
+			return err
+		}
+
+		delete(config.Contexts, name)
+
+		if config.Current == name {
+			config.Current = ""
+		}
+	}
+
+	return config.Save()
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type show struct {
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("context.show", &show{})
+}
+
+func (cmd *show) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *show) Process(ctx context.Context) error {
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *show) Usage() string {
+	return "[NAME]"
+}
+
+func (cmd *show) Description() string {
+	return `Show the settings of context NAME, defaults to the current context.
+
+The settings are output as GOVC_* environment variables, see also 'govc env'.
+
+Examples:
+  govc context.show
+  govc context.show -json lab
+  export $(govc context.show lab)`
+}
+
+func (cmd *show) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() > 1 {
+		return flag.ErrHelp
+	}
+
+	config, err := flags.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := f.Arg(0)
+	if name == "" {
+		name = config.CurrentContextName()
+		if name == "" {
+			return errors.New("no current context")
+		}
+	}
+
+	c, err := config.Context(name)
+	if err != nil {
+		return err
+	}
+
+	return cmd.WriteResult(&showResult{c})
+}
+
+type showResult struct {
+	*flags.Context
+}
+
+func (r *showResult) Write(w io.Writer) error {
+	for _, e := range r.Environ() {
+		fmt.Fprintln(w, e)
+	}
+	return nil
+}
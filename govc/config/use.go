@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type use struct {
+	*flags.EmptyFlag
+}
+
+func init() {
+	cli.Register("context.use", &use{})
+}
+
+func (cmd *use) Usage() string {
+	return "NAME"
+}
+
+func (cmd *use) Description() string {
+	return `Set the current context to NAME.
+
+Settings of the current context are used as defaults for GOVC_* environment variables
+that are not set.  The GOVC_CONTEXT environment variable overrides the current context.
+
+Examples:
+  govc context.use prod
+  GOVC_CONTEXT=lab govc ls`
+}
+
+func (cmd *use) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	config, err := flags.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := f.Arg(0)
+
+	if _, err = config.Context(name); err != nil {
+		return err
+	}
+
+	config.Current = name
+
+	return config.Save()
+}
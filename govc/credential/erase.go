@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type erase struct {
+	*flags.ClientFlag
+}
+
+func init() {
+	cli.Register("credential.erase", &erase{})
+}
+
+func (cmd *erase) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+}
+
+func (cmd *erase) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *erase) Description() string {
+	return `Erase the password for GOVC_URL using the credential helper.
+
+If GOVC_USERNAME is not set, passwords for all users of GOVC_URL are erased.
+
+Examples:
+  govc credential.erase -u admin@vc.example.com`
+}
+
+func (cmd *erase) Run(ctx context.Context, f *flag.FlagSet) error {
+	cred := cmd.Credential()
+	cred.Password = ""
+
+	return helper(cmd.ClientFlag).Erase(cred)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type ls struct {
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("credential.ls", &ls{})
+}
+
+func (cmd *ls) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *ls) Process(ctx context.Context) error {
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *ls) Description() string {
+	return `List the URLs and usernames saved in the builtin credential store.
+
+Passwords are not displayed.
+
+Examples:
+  govc credential.ls`
+}
+
+func (cmd *ls) Run(ctx context.Context, f *flag.FlagSet) error {
+	creds, err := flags.NewFileCredentialStore().List()
+	if err != nil {
+		return err
+	}
+
+	var res lsResult
+	for _, c := range creds {
+		res = append(res, lsEntry{c.Protocol, c.Host, c.Username})
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type lsEntry struct {
+	Protocol string
+	Host     string
+	Username string
+}
+
+type lsResult []lsEntry
+
+func (r lsResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, c := range r {
+		fmt.Fprintf(tw, "%s://%s\t%s\n", c.Protocol, c.Host, c.Username)
+	}
+
+	return tw.Flush()
+}
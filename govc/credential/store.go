@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type store struct {
+	*flags.ClientFlag
+}
+
+func init() {
+	cli.Register("credential.store", &store{})
+}
+
+func (cmd *store) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+}
+
+func (cmd *store) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *store) Description() string {
+	return `Store the password for GOVC_URL and GOVC_USERNAME using the credential helper.
+
+The password is read from the first line of stdin, unless included in GOVC_URL or GOVC_PASSWORD.
+If no credential helper is configured, the builtin "store" helper is used, which saves passwords
+in ~/.govmomi/credentials encrypted with a key in ~/.govmomi/credentials.key or derived from
+the GOVC_CREDENTIAL_KEY passphrase.
+
+Other helpers are run as "govc-credential-NAME get|store|erase", reading and writing "key=value"
+lines, as git credential helpers do.  For example, a helper can use the OS keyring.
+
+Examples:
+  read -s password && echo "$password" | govc credential.store -u admin@vc.example.com
+  export GOVC_CREDENTIAL_HELPER=store
+  govc ls -u admin@vc.example.com
+  govc credential.store -credential-helper keyring < password.txt`
+}
+
+func helper(cmd *flags.ClientFlag) flags.CredentialHelper {
+	if h := cmd.CredentialHelper(); h != nil {
+		return h
+	}
+
+	return flags.NewFileCredentialStore()
+}
+
+func (cmd *store) Run(ctx context.Context, f *flag.FlagSet) error {
+	u := cmd.URLWithoutPassword()
+
+	cred := cmd.Credential()
+	if cred.Username == "" {
+		return errors.New("specify a username")
+	}
+
+	if cred.Password == "" {
+		scanner := bufio.NewScanner(os.Stdin)
+		if scanner.Scan() {
+			cred.Password = scanner.Text()
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	if cred.Password == "" {
+		return errors.New("no password given for " + u.String())
+	}
+
+	return helper(cmd.ClientFlag).Store(cred)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customization
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type nics []object.CustomizationNIC
+
+func (n *nics) String() string {
+	return fmt.Sprint(*n)
+}
+
+func (n *nics) Set(value string) error {
+	var nic object.CustomizationNIC
+
+	if value != "dhcp" {
+		for _, kv := range strings.Split(value, ",") {
+			r := strings.SplitN(kv, "=", 2)
+			if len(r) != 2 {
+				return fmt.Errorf("failed to parse NIC setting: %s", kv)
+			}
+
+			switch r[0] {
+			case "ip":
+				nic.IP = r[1]
+			case "netmask":
+				nic.Netmask = r[1]
+			case "gateway":
+				nic.Gateway = append(nic.Gateway, r[1])
+			case "dns":
+				nic.DNS = append(nic.DNS, r[1])
+			default:
+				return fmt.Errorf("unknown NIC setting: %s", r[0])
+			}
+		}
+	}
+
+	*n = append(*n, nic)
+	return nil
+}
+
+type list []string
+
+func (l *list) String() string {
+	return fmt.Sprint(*l)
+}
+
+func (l *list) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type create struct {
+	*flags.VirtualMachineFlag
+
+	object.CustomizationSpecBuilder
+
+	description string
+	nics        nics
+	dns         list
+	suffix      list
+	force       bool
+}
+
+func init() {
+	cli.Register("customization.create", &create{})
+}
+
+func (cmd *create) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.VirtualMachineFlag, ctx = flags.NewVirtualMachineFlag(ctx)
+	cmd.VirtualMachineFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.description, "d", "", "Description")
+	f.BoolVar(&cmd.force, "f", false, "Overwrite existing spec")
+	f.BoolVar(&cmd.Windows, "windows", false, "Create a Windows (sysprep) spec, default is Linux")
+	f.StringVar(&cmd.Hostname, "hostname", "", "Guest hostname, defaults to the VM name")
+	f.StringVar(&cmd.Domain, "domain", "", "Guest domain name")
+	f.StringVar(&cmd.TimeZone, "tz", "", "Time zone (Olson name for Linux, index for Windows)")
+	f.Var(&cmd.dns, "dns", "DNS server (can be specified multiple times)")
+	f.Var(&cmd.suffix, "dns-suffix", "DNS search suffix (can be specified multiple times)")
+	f.Var(&cmd.nics, "nic", "NIC settings, in device order (can be specified multiple times)")
+	f.StringVar(&cmd.FullName, "fullname", "", "Windows user full name")
+	f.StringVar(&cmd.OrgName, "org", "", "Windows organization name")
+	f.StringVar(&cmd.ProductKey, "product-key", "", "Windows product key")
+	f.StringVar(&cmd.AdminPassword, "password", "", "Windows administrator password")
+	f.StringVar(&cmd.JoinDomain, "join-domain", "", "Windows domain to join")
+	f.StringVar(&cmd.DomainAdmin, "domain-admin", "", "Windows domain admin user")
+	f.StringVar(&cmd.DomainAdminPassword, "domain-password", "", "Windows domain admin password")
+}
+
+func (cmd *create) Process(ctx context.Context) error {
+	if err := cmd.VirtualMachineFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *create) Usage() string {
+	return "NAME"
+}
+
+func (cmd *create) Description() string {
+	return `Create customization specification NAME.
+
+NIC settings are applied to the VM's network adapters in device order.
+Each -nic flag is either "dhcp" or a comma separated list of ip, netmask, gateway and dns settings.
+If -vm is given, the number of NIC settings is validated against the VM's network adapters
+and DHCP is used for all adapters if no -nic flag is given.
+
+Examples:
+  govc customization.create -domain example.com -dns 10.0.0.2 -nic ip=10.0.0.10,netmask=255.255.255.0,gateway=10.0.0.1 web
+  govc customization.create -vm template-vm -nic dhcp -nic ip=192.168.1.10,netmask=255.255.255.0 multi-nic
+  govc customization.create -windows -tz 035 -password secret -join-domain corp.example.com \
+    -domain-admin admin -domain-password secret win-spec`
+}
+
+func (cmd *create) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	vm, err := cmd.VirtualMachine()
+	if err != nil {
+		return err
+	}
+
+	var devices object.VirtualDeviceList
+	if vm != nil {
+		devices, err = vm.Device(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.NICs = cmd.nics
+	cmd.DNS = cmd.dns
+	cmd.DNSSuffix = cmd.suffix
+
+	item, err := cmd.SpecItem(f.Arg(0), cmd.description, devices)
+	if err != nil {
+		return err
+	}
+
+	m := object.NewCustomizationSpecManager(c)
+
+	if cmd.force {
+		exists, err := m.DoesCustomizationSpecExist(ctx, item.Info.Name)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return m.OverwriteCustomizationSpec(ctx, *item)
+		}
+	}
+
+	return m.CreateCustomizationSpec(ctx, *item)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customization
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type importx struct {
+	*flags.ClientFlag
+
+	name  string
+	force bool
+}
+
+func init() {
+	cli.Register("customization.import", &importx{})
+}
+
+func (cmd *importx) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.name, "name", "", "Spec name, defaults to the name in FILE")
+	f.BoolVar(&cmd.force, "f", false, "Overwrite existing spec")
+}
+
+func (cmd *importx) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *importx) Usage() string {
+	return "FILE"
+}
+
+func (cmd *importx) Description() string {
+	return `Import customization specification from XML FILE, or stdin if FILE is "-".
+
+Examples:
+  govc customization.import my-spec.xml
+  govc customization.export my-spec | govc customization.import -name my-spec-copy -`
+}
+
+func (cmd *importx) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	var b []byte
+	var err error
+
+	if f.Arg(0) == "-" {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(f.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	m := object.NewCustomizationSpecManager(c)
+
+	item, err := m.XmlToCustomizationSpecItem(ctx, string(b))
+	if err != nil {
+		return err
+	}
+
+	if cmd.name != "" {
+		item.Info.Name = cmd.name
+	}
+
+	if cmd.force {
+		exists, err := m.DoesCustomizationSpecExist(ctx, item.Info.Name)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return m.OverwriteCustomizationSpec(ctx, *item)
+		}
+	}
+
+	return m.CreateCustomizationSpec(ctx, *item)
+}
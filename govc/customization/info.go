@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customization
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type info struct {
+	*flags.ClientFlag
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("customization.info", &info{})
+}
+
+func (cmd *info) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *info) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *info) Usage() string {
+	return "NAME"
+}
+
+func (cmd *info) Description() string {
+	return `Display customization specification NAME.
+
+Examples:
+  govc customization.info my-linux-spec
+  govc customization.info -json my-linux-spec`
+}
+
+func (cmd *info) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	m := object.NewCustomizationSpecManager(c)
+
+	item, err := m.GetCustomizationSpec(ctx, f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	return cmd.WriteResult(&infoResult{item})
+}
+
+type infoResult struct {
+	*types.CustomizationSpecItem
+}
+
+func customizationName(name types.BaseCustomizationName) string {
+	switch n := name.(type) {
+	case *types.CustomizationFixedName:
+		return n.Name
+	case *types.CustomizationVirtualMachineName:
+		return "<VM name>"
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%T", n)
+	}
+}
+
+func (r *infoResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Name:\t%s\n", r.Info.Name)
+	fmt.Fprintf(tw, "  Description:\t%s\n", r.Info.Description)
+	fmt.Fprintf(tw, "  Type:\t%s\n", r.Info.Type)
+
+	switch id := r.Spec.Identity.(type) {
+	case *types.CustomizationLinuxPrep:
+		fmt.Fprintf(tw, "  Hostname:\t%s\n", customizationName(id.HostName))
+		fmt.Fprintf(tw, "  Domain:\t%s\n", id.Domain)
+		fmt.Fprintf(tw, "  Time zone:\t%s\n", id.TimeZone)
+	case *types.CustomizationSysprep:
+		fmt.Fprintf(tw, "  Hostname:\t%s\n", customizationName(id.UserData.ComputerName))
+		fmt.Fprintf(tw, "  Full name:\t%s\n", id.UserData.FullName)
+		fmt.Fprintf(tw, "  Organization:\t%s\n", id.UserData.OrgName)
+		fmt.Fprintf(tw, "  Time zone:\t%d\n", id.GuiUnattended.TimeZone)
+		if id.Identification.JoinDomain != "" {
+			fmt.Fprintf(tw, "  Domain:\t%s\n", id.Identification.JoinDomain)
+		} else {
+			fmt.Fprintf(tw, "  Workgroup:\t%s\n", id.Identification.JoinWorkgroup)
+		}
+	}
+
+	fmt.Fprintf(tw, "  DNS servers:\t%s\n", strings.Join(r.Spec.GlobalIPSettings.DnsServerList, ", "))
+	fmt.Fprintf(tw, "  DNS suffixes:\t%s\n", strings.Join(r.Spec.GlobalIPSettings.DnsSuffixList, ", "))
+
+	for i, m := range r.Spec.NicSettingMap {
+		ip := "dhcp"
+		if fixed, ok := m.Adapter.Ip.(*types.CustomizationFixedIp); ok {
+			ip = fmt.Sprintf("%s/%s", fixed.IpAddress, m.Adapter.SubnetMask)
+			if len(m.Adapter.Gateway) != 0 {
+				ip += " gw " + strings.Join(m.Adapter.Gateway, ", ")
+			}
+		}
+		fmt.Fprintf(tw, "  NIC %d:\t%s\n", i, ip)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/units"
+	"golang.org/x/net/context"
+)
+
+type extend struct {
+	*flags.DatastoreFlag
+
+	bytes units.ByteSize
+	eager bool
+}
+
+func init() {
+	cli.Register("datastore.disk.extend", &extend{})
+}
+
+func (cmd *extend) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatastoreFlag, ctx = flags.NewDatastoreFlag(ctx)
+	cmd.DatastoreFlag.Register(ctx, f)
+
+	f.Var(&cmd.bytes, "size", "New size of disk")
+	f.BoolVar(&cmd.eager, "eager", false, "Eagerly zero the extended part of the disk")
+}
+
+func (cmd *extend) Process(ctx context.Context) error {
+	if err := cmd.DatastoreFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *extend) Usage() string {
+	return "VMDK"
+}
+
+func (cmd *extend) Description() string {
+	return `Extend VMDK to the given size.
+
+The disk must not be attached to a powered on VM, see 'govc vm.disk.change' to grow an attached disk.
+
+Examples:
+  govc datastore.disk.extend -size 20G vm1/vm1.vmdk
+  govc datastore.disk.extend -ds datastore2 -size 1T -eager db1/db1_1.vmdk`
+}
+
+func (cmd *extend) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	if cmd.bytes == 0 {
+		return errors.New("please specify a new disk size")
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	dc, err := cmd.Datacenter()
+	if err != nil {
+		return err
+	}
+
+	path, err := cmd.DatastorePath(f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	m := object.NewVirtualDiskManager(c)
+	task, err := m.ExtendVirtualDisk(ctx, path, dc, int64(cmd.bytes)/1024, &cmd.eager)
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+// fill implements the disk operations that take only a disk name and return a task:
+// inflate, eagerzero and zerofill.
+type fill struct {
+	*flags.DatastoreFlag
+
+	op          func(object.VirtualDiskManager, context.Context, string, *object.Datacenter) (*object.Task, error)
+	description string
+}
+
+func init() {
+	cli.Register("datastore.disk.inflate", &fill{
+		op:          object.VirtualDiskManager.InflateVirtualDisk,
+		description: "Inflate thin or sparse VMDK to its full size.",
+	})
+
+	cli.Register("datastore.disk.eagerzero", &fill{
+		op:          object.VirtualDiskManager.EagerZeroVirtualDisk,
+		description: "Convert lazy zeroed thick VMDK to eager zeroed thick.",
+	})
+
+	cli.Register("datastore.disk.zerofill", &fill{
+		op:          object.VirtualDiskManager.ZeroFillVirtualDisk,
+		description: "Overwrite all blocks of VMDK with zeroes.",
+	})
+}
+
+func (cmd *fill) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatastoreFlag, ctx = flags.NewDatastoreFlag(ctx)
+	cmd.DatastoreFlag.Register(ctx, f)
+}
+
+func (cmd *fill) Process(ctx context.Context) error {
+	if err := cmd.DatastoreFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *fill) Usage() string {
+	return "VMDK"
+}
+
+func (cmd *fill) Description() string {
+	return cmd.description
+}
+
+func (cmd *fill) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	dc, err := cmd.Datacenter()
+	if err != nil {
+		return err
+	}
+
+	path, err := cmd.DatastorePath(f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	task, err := cmd.op(*object.NewVirtualDiskManager(c), ctx, path, dc)
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
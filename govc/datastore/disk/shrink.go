@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type shrink struct {
+	*flags.DatastoreFlag
+
+	copy bool
+}
+
+func init() {
+	cli.Register("datastore.disk.shrink", &shrink{})
+}
+
+func (cmd *shrink) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatastoreFlag, ctx = flags.NewDatastoreFlag(ctx)
+	cmd.DatastoreFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.copy, "copy", false, "Shrink by copying to a new disk, requires additional space")
+}
+
+func (cmd *shrink) Process(ctx context.Context) error {
+	if err := cmd.DatastoreFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *shrink) Usage() string {
+	return "VMDK"
+}
+
+func (cmd *shrink) Description() string {
+	return `Shrink sparse VMDK, reclaiming unused space.
+
+Examples:
+  govc datastore.disk.shrink vm1/vm1.vmdk
+  govc datastore.disk.shrink -copy vm1/vm1.vmdk`
+}
+
+func (cmd *shrink) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	dc, err := cmd.Datacenter()
+	if err != nil {
+		return err
+	}
+
+	path, err := cmd.DatastorePath(f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	m := object.NewVirtualDiskManager(c)
+	task, err := m.ShrinkVirtualDisk(ctx, path, dc, &cmd.copy)
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
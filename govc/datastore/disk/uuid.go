@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type uuid struct {
+	*flags.DatastoreFlag
+
+	uuid string
+}
+
+func init() {
+	cli.Register("datastore.disk.uuid", &uuid{})
+}
+
+func (cmd *uuid) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatastoreFlag, ctx = flags.NewDatastoreFlag(ctx)
+	cmd.DatastoreFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.uuid, "uuid", "", "Set disk UUID")
+}
+
+func (cmd *uuid) Process(ctx context.Context) error {
+	if err := cmd.DatastoreFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *uuid) Usage() string {
+	return "VMDK"
+}
+
+func (cmd *uuid) Description() string {
+	return `Query or set the UUID of VMDK.
+
+Examples:
+  govc datastore.disk.uuid vm1/vm1.vmdk
+  govc datastore.disk.uuid -uuid "60 00 C2 9b 69 2f 16 2a-2c 8d 07 ab 3f 0e 8a 8c" vm1/vm1.vmdk`
+}
+
+func (cmd *uuid) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	dc, err := cmd.Datacenter()
+	if err != nil {
+		return err
+	}
+
+	path, err := cmd.DatastorePath(f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	m := object.NewVirtualDiskManager(c)
+
+	if cmd.uuid != "" {
+		return m.SetVirtualDiskUuid(ctx, path, dc, cmd.uuid)
+	}
+
+	id, err := m.QueryVirtualDiskUuid(ctx, path, dc)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(id)
+
+	return nil
+}
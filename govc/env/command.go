@@ -62,7 +62,14 @@ func (cmd *env) Process(ctx context.Context) error {
 func (cmd *env) Description() string {
 	return `Output the environment variables for this client.
 If credentials are included in the url, they are split into separate variables.
-Useful as bash scripting helper to parse GOVC_URL.`
+Useful as bash scripting helper to parse GOVC_URL.
+
+Variables that are not set in the environment are taken from the current context,
+so a context can be exported using GOVC_CONTEXT, see also 'govc context.show'.
+
+Examples:
+  govc env
+  export $(GOVC_CONTEXT=lab govc env)`
 }
 
 func (cmd *env) Run(ctx context.Context, f *flag.FlagSet) error {
@@ -0,0 +1,346 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package find
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/list"
+	"github.com/RotatingFans/govmomi/property"
+	"github.com/RotatingFans/govmomi/view"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+var kinds = map[string]string{
+	"a": "VirtualApp",
+	"c": "ClusterComputeResource",
+	"d": "Datacenter",
+	"f": "Folder",
+	"g": "DistributedVirtualPortgroup",
+	"h": "HostSystem",
+	"m": "VirtualMachine",
+	"n": "Network",
+	"o": "OpaqueNetwork",
+	"p": "ResourcePool",
+	"r": "ComputeResource",
+	"s": "Datastore",
+	"w": "DistributedVirtualSwitch",
+}
+
+type kindFlag []string
+
+func (k *kindFlag) String() string {
+	return fmt.Sprint(*k)
+}
+
+func (k *kindFlag) Set(value string) error {
+	if kind, ok := kinds[value]; ok {
+		value = kind
+	}
+
+	*k = append(*k, value)
+	return nil
+}
+
+type find struct {
+	*flags.DatacenterFlag
+
+	kind     kindFlag
+	name     string
+	maxdepth int
+	ref      bool
+
+	filter property.Filter
+}
+
+func init() {
+	cli.Register("find", &find{})
+}
+
+func (cmd *find) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+
+	f.Var(&cmd.kind, "type", "Resource type (can be specified multiple times)")
+	f.StringVar(&cmd.name, "name", "", "Resource name pattern")
+	f.IntVar(&cmd.maxdepth, "maxdepth", -1, "Max depth below ROOT, 1 searches only the children of ROOT")
+	f.BoolVar(&cmd.ref, "i", false, "Print the managed object reference rather than the inventory path")
+}
+
+func (cmd *find) Process(ctx context.Context) error {
+	if err := cmd.DatacenterFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *find) Usage() string {
+	return "[ROOT] [-KEY VAL]..."
+}
+
+func (cmd *find) Description() string {
+	var abbr []string
+	for k, v := range kinds {
+		abbr = append(abbr, fmt.Sprintf("  %s    %s", k, v))
+	}
+	sort.Strings(abbr)
+
+	return `Find managed objects below ROOT, which defaults to the current datacenter or root folder.
+
+Objects are found using a recursive ContainerView, matching the given property filters.
+Any KEY that is not an option is a property path of the given type to match against VAL,
+property filters require the '-type' flag.  String values can use wildcard patterns,
+numeric values can be prefixed with a comparison operator such as '>' or '<='.
+
+The '-type' flag value can be a managed object type or one of the following aliases:
+
+` + strings.Join(abbr, "\n") + `
+
+Examples:
+  govc find -type m
+  govc find -type m -runtime.powerState poweredOn
+  govc find / -type m -name 'web-*' -runtime.powerState poweredOn -config.hardware.numCPU '>4'
+  govc find /dc1/host -type h -runtime.inMaintenanceMode true
+  govc find . -type s -summary.accessible false
+  govc find -maxdepth 1 -i /dc1/vm`
+}
+
+// Parse implements cli.FlagParser, removing property filters from args before parsing flags.
+func (cmd *find) Parse(f *flag.FlagSet, args []string) error {
+	var flags, params []string
+	cmd.filter = property.Filter{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			params = append(params, args[i+1:]...)
+			break
+		}
+
+		if arg == "-" || !strings.HasPrefix(arg, "-") {
+			params = append(params, arg)
+			continue
+		}
+
+		key := strings.TrimLeft(arg, "-")
+		var val string
+		hasVal := false
+
+		if kv := strings.SplitN(key, "=", 2); len(kv) == 2 {
+			key, val, hasVal = kv[0], kv[1], true
+		}
+
+		if fl := f.Lookup(key); fl != nil || key == "h" || key == "help" {
+			flags = append(flags, arg)
+
+			if fl != nil && !hasVal {
+				b, ok := fl.Value.(interface {
+					IsBoolFlag() bool
+				})
+				if !(ok && b.IsBoolFlag()) && i+1 < len(args) {
+					i++
+					flags = append(flags, args[i])
+				}
+			}
+
+			continue
+		}
+
+		if !hasVal {
+			if i+1 == len(args) {
+				return fmt.Errorf("missing value for %s", arg)
+			}
+			i++
+			val = args[i]
+		}
+
+		cmd.filter[key] = val
+	}
+
+	return f.Parse(append(append(flags, "--"), params...))
+}
+
+func (cmd *find) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() > 1 {
+		return flag.ErrHelp
+	}
+
+	root := "."
+	if f.NArg() == 1 {
+		root = f.Arg(0)
+	}
+
+	filter := property.Filter{}
+	for key, val := range cmd.filter {
+		filter[key] = val
+	}
+
+	if len(filter) != 0 && len(cmd.kind) == 0 {
+		return errors.New("property filters require -type")
+	}
+
+	if cmd.name != "" {
+		filter["name"] = cmd.name
+	}
+
+	if cmd.maxdepth == 0 {
+		return errors.New("-maxdepth must be at least 1")
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	finder, err := cmd.Finder()
+	if err != nil {
+		return err
+	}
+
+	roots, err := finder.ManagedObjectList(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	if len(roots) == 0 {
+		return fmt.Errorf("%s not found", root)
+	}
+
+	m := view.NewManager(c)
+	var res findResult
+
+	for _, e := range roots {
+		es, err := cmd.find(ctx, m, e, filter)
+		if err != nil {
+			return err
+		}
+
+		res.Elements = append(res.Elements, es...)
+	}
+
+	res.ref = cmd.ref
+
+	return cmd.WriteResult(&res)
+}
+
+func (cmd *find) find(ctx context.Context, m *view.Manager, root list.Element, filter property.Filter) ([]findElement, error) {
+	v, err := m.CreateContainerView(ctx, root.Object.Reference(), nil, cmd.maxdepth != 1)
+	if err != nil {
+		return nil, err
+	}
+
+	defer v.Destroy(ctx)
+
+	refs, err := v.Find(ctx, cmd.kind, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	// Inventory paths are built from the name and parent of all entities in the view
+	var entities []mo.ManagedEntity
+	err = v.Retrieve(ctx, nil, []string{"name", "parent"}, &entities)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := make(map[types.ManagedObjectReference]mo.ManagedEntity, len(entities))
+	for _, e := range entities {
+		inventory[e.Self] = e
+	}
+
+	var inventoryPath func(ref types.ManagedObjectReference) (string, bool)
+	inventoryPath = func(ref types.ManagedObjectReference) (string, bool) {
+		if ref == root.Object.Reference() {
+			return root.Path, true
+		}
+
+		e, ok := inventory[ref]
+		if !ok || e.Parent == nil {
+			return "", false
+		}
+
+		p, ok := inventoryPath(*e.Parent)
+		return path.Join(p, e.Name), ok
+	}
+
+	var res []findElement
+
+	for _, ref := range refs {
+		p, ok := inventoryPath(ref)
+		if !ok {
+			// For example, a VM in a vApp has no parent folder
+			p = path.Join(root.Path, inventory[ref].Name)
+		}
+
+		if cmd.maxdepth > 0 {
+			rel := strings.TrimPrefix(strings.TrimPrefix(p, root.Path), "/")
+			if strings.Count(rel, "/")+1 > cmd.maxdepth {
+				continue
+			}
+		}
+
+		res = append(res, findElement{Path: p, Object: ref})
+	}
+
+	sort.Sort(byPath(res))
+
+	return res, nil
+}
+
+type findElement struct {
+	Path   string
+	Object types.ManagedObjectReference
+}
+
+type byPath []findElement
+
+func (s byPath) Len() int           { return len(s) }
+func (s byPath) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byPath) Less(i, j int) bool { return s[i].Path < s[j].Path }
+
+type findResult struct {
+	Elements []findElement
+
+	ref bool
+}
+
+func (r *findResult) Write(w io.Writer) error {
+	for _, e := range r.Elements {
+		if r.ref {
+			fmt.Fprintln(w, e.Object.String())
+		} else {
+			fmt.Fprintln(w, e.Path)
+		}
+	}
+
+	return nil
+}
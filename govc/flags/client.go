@@ -47,6 +47,10 @@ const (
 	envMinAPIVersion = "GOVC_MIN_API_VERSION"
 	envVimNamespace  = "GOVC_VIM_NAMESPACE"
 	envVimVersion    = "GOVC_VIM_VERSION"
+	envThumbprint    = "GOVC_THUMBPRINT"
+	envDryRun        = "GOVC_DRY_RUN"
+
+	envCredentialHelper = "GOVC_CREDENTIAL_HELPER"
 )
 
 const cDescr = "ESX or vCenter URL"
@@ -62,22 +66,38 @@ type ClientFlag struct {
 	cert          string
 	key           string
 	insecure      bool
+	thumbprint    string
+	helper        string
 	persist       bool
 	minAPIVersion string
 	vimNamespace  string
 	vimVersion    string
+	dryRun        string
 
 	client *vim25.Client
+	shared bool
 }
 
 var clientFlagKey = flagKey("client")
 
+var sharedClientKey = flagKey("client.shared")
+
+// ContextWithClient returns a context in which new ClientFlags use the given client, rather than
+// creating or restoring a session.  The shared client is not logged out by the ClientFlag.
+func ContextWithClient(ctx context.Context, c *vim25.Client) context.Context {
+	return context.WithValue(ctx, sharedClientKey, c)
+}
+
 func NewClientFlag(ctx context.Context) (*ClientFlag, context.Context) {
 	if v := ctx.Value(clientFlagKey); v != nil {
 		return v.(*ClientFlag), ctx
 	}
 
 	v := &ClientFlag{}
+	if c, ok := ctx.Value(sharedClientKey).(*vim25.Client); ok {
+		v.client = c
+		v.shared = true
+	}
 	v.DebugFlag, ctx = NewDebugFlag(ctx)
 	ctx = context.WithValue(ctx, clientFlagKey, v)
 	return v, ctx
@@ -115,31 +135,31 @@ func (flag *ClientFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		flag.DebugFlag.Register(ctx, f)
 
 		{
-			flag.Set(os.Getenv(envURL))
+			flag.Set(getenv(envURL))
 			usage := fmt.Sprintf("%s [%s]", cDescr, envURL)
 			f.Var(flag, "u", usage)
 		}
 
 		{
-			flag.username = os.Getenv(envUsername)
-			flag.password = os.Getenv(envPassword)
+			flag.username = getenv(envUsername)
+			flag.password = getenv(envPassword)
 		}
 
 		{
-			value := os.Getenv(envCertificate)
+			value := getenv(envCertificate)
 			usage := fmt.Sprintf("Certificate [%s]", envCertificate)
 			f.StringVar(&flag.cert, "cert", value, usage)
 		}
 
 		{
-			value := os.Getenv(envPrivateKey)
+			value := getenv(envPrivateKey)
 			usage := fmt.Sprintf("Private key [%s]", envPrivateKey)
 			f.StringVar(&flag.key, "key", value, usage)
 		}
 
 		{
 			insecure := false
-			switch env := strings.ToLower(os.Getenv(envInsecure)); env {
+			switch env := strings.ToLower(getenv(envInsecure)); env {
 			case "1", "true":
 				insecure = true
 			}
@@ -148,9 +168,21 @@ func (flag *ClientFlag) Register(ctx context.Context, f *flag.FlagSet) {
 			f.BoolVar(&flag.insecure, "k", insecure, usage)
 		}
 
+		{
+			value := getenv(envCredentialHelper)
+			usage := fmt.Sprintf("Credential helper command [%s]", envCredentialHelper)
+			f.StringVar(&flag.helper, "credential-helper", value, usage)
+		}
+
+		{
+			value := getenv(envThumbprint)
+			usage := fmt.Sprintf("SHA1 thumbprint of the server certificate [%s]", envThumbprint)
+			f.StringVar(&flag.thumbprint, "thumbprint", value, usage)
+		}
+
 		{
 			persist := true
-			switch env := strings.ToLower(os.Getenv(envPersist)); env {
+			switch env := strings.ToLower(getenv(envPersist)); env {
 			case "0", "false":
 				persist = false
 			}
@@ -160,7 +192,7 @@ func (flag *ClientFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		}
 
 		{
-			env := os.Getenv(envMinAPIVersion)
+			env := getenv(envMinAPIVersion)
 			if env == "" {
 				env = "5.5"
 			}
@@ -169,7 +201,13 @@ func (flag *ClientFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		}
 
 		{
-			value := os.Getenv(envVimNamespace)
+			_ = (*dryRunValue)(&flag.dryRun).Set(getenv(envDryRun))
+			usage := fmt.Sprintf("Print requests that change state as xml or json to stderr, instead of sending them [%s]", envDryRun)
+			f.Var((*dryRunValue)(&flag.dryRun), "dry-run", usage)
+		}
+
+		{
+			value := getenv(envVimNamespace)
 			if value == "" {
 				value = soap.DefaultVimNamespace
 			}
@@ -178,7 +216,7 @@ func (flag *ClientFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		}
 
 		{
-			value := os.Getenv(envVimVersion)
+			value := getenv(envVimVersion)
 			if value == "" {
 				value = soap.DefaultVimVersion
 			}
@@ -194,6 +232,14 @@ func (flag *ClientFlag) Process(ctx context.Context) error {
 			return err
 		}
 
+		if err := contextError(); err != nil {
+			return err
+		}
+
+		if flag.shared {
+			return nil
+		}
+
 		if flag.url == nil {
 			return errors.New("specify an " + cDescr)
 		}
@@ -229,6 +275,31 @@ func (flag *ClientFlag) Process(ctx context.Context) error {
 	})
 }
 
+// dryRunValue is the -dry-run flag value, which can be given without a format to default to xml.
+type dryRunValue string
+
+func (v *dryRunValue) String() string {
+	return string(*v)
+}
+
+func (v *dryRunValue) Set(s string) error {
+	switch strings.ToLower(s) {
+	case "", "0", "false":
+		*v = ""
+	case "1", "true", "xml":
+		*v = "xml"
+	case "json":
+		*v = "json"
+	default:
+		return fmt.Errorf("invalid dry-run format: %s", s)
+	}
+	return nil
+}
+
+func (v *dryRunValue) IsBoolFlag() bool {
+	return true
+}
+
 // Retry twice when a temporary I/O error occurs.
 // This means a maximum of 3 attempts.
 func attachRetries(rt soap.RoundTripper) soap.RoundTripper {
@@ -333,6 +404,7 @@ func (flag *ClientFlag) loadClient() (*vim25.Client, error) {
 
 func (flag *ClientFlag) newClient() (*vim25.Client, error) {
 	sc := soap.NewClient(flag.url, flag.insecure)
+	sc.SetThumbprint(flag.thumbprint)
 	isTunnel := false
 
 	if flag.cert != "" {
@@ -361,6 +433,8 @@ func (flag *ClientFlag) newClient() (*vim25.Client, error) {
 
 	m := session.NewManager(c)
 	u := flag.url.User
+	var cred *Credential
+	fromHelper := false
 
 	if u.Username() == "" {
 		// Assume we are running on an ESX or Workstation host if no username is provided
@@ -368,6 +442,20 @@ func (flag *ClientFlag) newClient() (*vim25.Client, error) {
 		if err != nil {
 			return nil, err
 		}
+	} else if flag.helper != "" && !isTunnel {
+		cred = flag.Credential()
+
+		if cred.Password == "" {
+			// Password is fetched here rather than in Process, so it is only used when a session is not restored
+			// and is never exposed via Environ.
+			if err = flag.CredentialHelper().Get(cred); err != nil {
+				return nil, err
+			}
+			if cred.Password != "" {
+				u = url.UserPassword(cred.Username, cred.Password)
+				fromHelper = true
+			}
+		}
 	}
 
 	if isTunnel {
@@ -378,8 +466,21 @@ func (flag *ClientFlag) newClient() (*vim25.Client, error) {
 	} else {
 		err = m.Login(context.TODO(), u)
 		if err != nil {
+			if fromHelper && soap.IsSoapFault(err) {
+				// Erase the password provided by the credential helper, only if it was rejected
+				if _, ok := soap.ToSoapFault(err).VimFault().(types.InvalidLogin); ok {
+					_ = flag.CredentialHelper().Erase(cred)
+				}
+			}
 			return nil, err
 		}
+
+		// Store the password given by -u or GOVC_PASSWORD, once login has succeeded
+		if cred != nil && cred.Password != "" && !fromHelper {
+			if err = flag.CredentialHelper().Store(cred); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	err = flag.saveClient(c)
@@ -390,6 +491,28 @@ func (flag *ClientFlag) newClient() (*vim25.Client, error) {
 	return c, nil
 }
 
+// Credential returns the credential helper input for this client,
+// including the password if given via the URL or GOVC_PASSWORD.
+func (flag *ClientFlag) Credential() *Credential {
+	password, _ := flag.url.User.Password()
+
+	return &Credential{
+		Protocol: flag.url.Scheme,
+		Host:     flag.url.Host,
+		Username: flag.url.User.Username(),
+		Password: password,
+	}
+}
+
+// CredentialHelper returns the credential helper for this client, or nil if none is configured.
+func (flag *ClientFlag) CredentialHelper() CredentialHelper {
+	if flag.helper == "" {
+		return nil
+	}
+
+	return NewCredentialHelper(flag.helper)
+}
+
 func (flag *ClientFlag) localTicket(ctx context.Context, m *session.Manager) (*url.Userinfo, error) {
 	ticket, err := m.AcquireLocalTicket(ctx, os.Getenv("USER"))
 	if err != nil {
@@ -463,12 +586,20 @@ func (flag *ClientFlag) Client() (*vim25.Client, error) {
 		return nil, err
 	}
 
+	if flag.dryRun != "" {
+		format := vim25.DryRunXML
+		if flag.dryRun == "json" {
+			format = vim25.DryRunJSON
+		}
+		c.RoundTripper = vim25.DryRun(c.RoundTripper, os.Stderr, format)
+	}
+
 	flag.client = c
 	return flag.client, nil
 }
 
 func (flag *ClientFlag) Logout(ctx context.Context) error {
-	if flag.persist || flag.client == nil {
+	if flag.persist || flag.shared || flag.client == nil {
 		return nil
 	}
 
@@ -516,10 +647,18 @@ func (flag *ClientFlag) Environ(extra bool) []string {
 		envMinAPIVersion,
 		envVimNamespace,
 		envVimVersion,
+		envThumbprint,
+		envCredentialHelper,
+		"GOVC_DATACENTER",
+		"GOVC_DATASTORE",
+		"GOVC_NETWORK",
+		"GOVC_RESOURCE_POOL",
+		"GOVC_FOLDER",
+		"GOVC_HOST",
 	}
 
 	for _, k := range keys {
-		if v := os.Getenv(k); v != "" {
+		if v := getenv(k); v != "" {
 			add(k, v)
 		}
 	}
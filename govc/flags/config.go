@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const (
+	envConfig  = "GOVC_CONFIG"
+	envContext = "GOVC_CONTEXT"
+)
+
+// Context is a named set of connection settings and defaults, stored in the govc config file.
+// Each setting provides the default for the corresponding GOVC_* environment variable.
+type Context struct {
+	URL              string `json:"url,omitempty"`
+	Username         string `json:"username,omitempty"`
+	CredentialHelper string `json:"credential-helper,omitempty"`
+	Insecure         bool   `json:"insecure,omitempty"`
+	Thumbprint       string `json:"thumbprint,omitempty"`
+	Datacenter       string `json:"datacenter,omitempty"`
+	Datastore        string `json:"datastore,omitempty"`
+	Network          string `json:"network,omitempty"`
+	ResourcePool     string `json:"resource-pool,omitempty"`
+	Folder           string `json:"folder,omitempty"`
+	Host             string `json:"host,omitempty"`
+}
+
+// Env returns the context settings keyed by their GOVC_* environment variable name.
+func (c *Context) Env() map[string]string {
+	env := map[string]string{
+		envURL:               c.URL,
+		envUsername:          c.Username,
+		envCredentialHelper:  c.CredentialHelper,
+		envThumbprint:        c.Thumbprint,
+		"GOVC_DATACENTER":    c.Datacenter,
+		"GOVC_DATASTORE":     c.Datastore,
+		"GOVC_NETWORK":       c.Network,
+		"GOVC_RESOURCE_POOL": c.ResourcePool,
+		"GOVC_FOLDER":        c.Folder,
+		"GOVC_HOST":          c.Host,
+	}
+
+	if c.Insecure {
+		env[envInsecure] = strconv.FormatBool(c.Insecure)
+	}
+
+	for k, v := range env {
+		if v == "" {
+			delete(env, k)
+		}
+	}
+
+	return env
+}
+
+// Environ returns the context settings in "key=value" form, sorted by key.
+func (c *Context) Environ() []string {
+	var env []string
+
+	for k, v := range c.Env() {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	sort.Strings(env)
+
+	return env
+}
+
+// Config is the govc config file, by default ~/.govmomi/config
+type Config struct {
+	Current  string              `json:"current,omitempty"`
+	Contexts map[string]*Context `json:"contexts"`
+}
+
+// ConfigFile returns the path of the config file, which can be changed via the GOVC_CONFIG env var.
+func ConfigFile() string {
+	if p := os.Getenv(envConfig); p != "" {
+		return p
+	}
+
+	return filepath.Join(os.Getenv("HOME"), ".govmomi", "config")
+}
+
+// LoadConfig reads the config file, returning an empty Config if the file does not exist.
+func LoadConfig() (*Config, error) {
+	config := &Config{
+		Contexts: make(map[string]*Context),
+	}
+
+	b, err := ioutil.ReadFile(ConfigFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("%s: %s", ConfigFile(), err)
+	}
+
+	if config.Contexts == nil {
+		config.Contexts = make(map[string]*Context)
+	}
+
+	return config, nil
+}
+
+// Save writes the config file.
+func (c *Config) Save() error {
+	p := ConfigFile()
+
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(p, append(b, '\n'), 0600)
+}
+
+// Context returns the context with the given name.
+func (c *Config) Context(name string) (*Context, error) {
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("context %q not found in %s", name, ConfigFile())
+	}
+
+	return ctx, nil
+}
+
+// CurrentContextName returns the name of the current context, from the GOVC_CONTEXT env var
+// or the config file.
+func (c *Config) CurrentContextName() string {
+	if name := os.Getenv(envContext); name != "" {
+		return name
+	}
+
+	return c.Current
+}
+
+var current struct {
+	sync.Once
+	env map[string]string
+	err error
+}
+
+func loadCurrentContext() {
+	config, err := LoadConfig()
+	if err != nil {
+		current.err = err
+		return
+	}
+
+	name := config.CurrentContextName()
+	if name == "" {
+		return
+	}
+
+	ctx, err := config.Context(name)
+	if err != nil {
+		current.err = err
+		return
+	}
+
+	current.env = ctx.Env()
+}
+
+// contextError returns any error loading the current context.
+func contextError() error {
+	current.Do(loadCurrentContext)
+	return current.err
+}
+
+// getenv returns the value of the given environment variable if set,
+// otherwise the value provided by the current context, if any.
+func getenv(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	current.Do(loadCurrentContext)
+	return current.env[key]
+}
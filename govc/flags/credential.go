@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Credential is the input and output of a credential helper,
+// using the same "key=value" line protocol as git credential helpers.
+type Credential struct {
+	Protocol string
+	Host     string
+	Username string
+	Password string
+}
+
+func (c *Credential) encode(w io.Writer) error {
+	fields := []struct{ key, val string }{
+		{"protocol", c.Protocol},
+		{"host", c.Host},
+		{"username", c.Username},
+		{"password", c.Password},
+	}
+
+	for _, f := range fields {
+		if f.val == "" {
+			continue
+		}
+		if strings.ContainsAny(f.val, "\n\x00") {
+			return fmt.Errorf("credential %s contains an invalid character", f.key)
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", f.key, f.val); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func (c *Credential) decode(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid credential helper output: %q", line)
+		}
+
+		switch kv[0] {
+		case "protocol":
+			c.Protocol = kv[1]
+		case "host":
+			c.Host = kv[1]
+		case "username":
+			c.Username = kv[1]
+		case "password":
+			c.Password = kv[1]
+		}
+	}
+
+	return scanner.Err()
+}
+
+// CredentialHelper gets, stores and erases passwords for a URL.
+type CredentialHelper interface {
+	// Get fills in the password of the given credential, if found.
+	Get(c *Credential) error
+	Store(c *Credential) error
+	Erase(c *Credential) error
+}
+
+// NewCredentialHelper returns the CredentialHelper for the given GOVC_CREDENTIAL_HELPER value:
+//
+// "store" is the builtin encrypted file store, see FileCredentialStore.
+//
+// A value starting with '!' is run as a shell command, with the action appended.
+//
+// Otherwise the value is a command with optional arguments, to which the action is appended.
+// If the command is not a path, it is prefixed with "govc-credential-", such that
+// "keyring" runs "govc-credential-keyring get" for example.
+func NewCredentialHelper(helper string) CredentialHelper {
+	if helper == "store" {
+		return NewFileCredentialStore()
+	}
+
+	return &execCredentialHelper{helper}
+}
+
+type execCredentialHelper struct {
+	helper string
+}
+
+func (h *execCredentialHelper) command(action string) (*exec.Cmd, error) {
+	if strings.HasPrefix(h.helper, "!") {
+		script := strings.TrimSpace(h.helper[1:])
+		if script == "" {
+			return nil, errors.New("empty credential helper command")
+		}
+		return exec.Command("/bin/sh", "-c", script+" "+action), nil
+	}
+
+	args := strings.Fields(h.helper)
+	if len(args) == 0 {
+		return nil, errors.New("empty credential helper command")
+	}
+
+	if !strings.ContainsRune(args[0], os.PathSeparator) {
+		args[0] = "govc-credential-" + args[0]
+	}
+
+	return exec.Command(args[0], append(args[1:], action)...), nil
+}
+
+func (h *execCredentialHelper) run(action string, c *Credential) error {
+	var in, out bytes.Buffer
+
+	if err := c.encode(&in); err != nil {
+		return err
+	}
+
+	cmd, err := h.command(action)
+	if err != nil {
+		return err
+	}
+
+	cmd.Stdin = &in
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("credential helper %q %s: %s", h.helper, action, err)
+	}
+
+	if action == "get" {
+		return c.decode(&out)
+	}
+
+	return nil
+}
+
+func (h *execCredentialHelper) Get(c *Credential) error {
+	return h.run("get", c)
+}
+
+func (h *execCredentialHelper) Store(c *Credential) error {
+	return h.run("store", c)
+}
+
+func (h *execCredentialHelper) Erase(c *Credential) error {
+	return h.run("erase", c)
+}
@@ -0,0 +1,264 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	envCredentialKey = "GOVC_CREDENTIAL_KEY"
+
+	credentialSaltSize = 16
+	credentialKeyIter  = 100000
+)
+
+// FileCredentialStore is a CredentialHelper that stores passwords in a file encrypted with AES-GCM.
+// The key is derived from the GOVC_CREDENTIAL_KEY passphrase with PBKDF2 if set, using a random
+// salt stored at the start of the file. Otherwise a random key is generated and stored in the
+// key file, readable only by the user.
+type FileCredentialStore struct {
+	Path    string
+	KeyPath string
+}
+
+// NewFileCredentialStore returns a FileCredentialStore using ~/.govmomi/credentials
+func NewFileCredentialStore() *FileCredentialStore {
+	dir := filepath.Join(os.Getenv("HOME"), ".govmomi")
+
+	return &FileCredentialStore{
+		Path:    filepath.Join(dir, "credentials"),
+		KeyPath: filepath.Join(dir, "credentials.key"),
+	}
+}
+
+// pbkdf2 derives a key of the given size from password and salt, using PBKDF2 with HMAC-SHA256 (RFC 8018).
+func pbkdf2(password, salt []byte, iter, size int) []byte {
+	prf := hmac.New(sha256.New, password)
+	var key []byte
+
+	for block := uint32(1); len(key) < size; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.Write(prf, binary.BigEndian, block)
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+
+		for i := 1; i < iter; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key = append(key, t...)
+	}
+
+	return key[:size]
+}
+
+func (s *FileCredentialStore) key(salt []byte) ([]byte, error) {
+	if pass := os.Getenv(envCredentialKey); pass != "" {
+		return pbkdf2([]byte(pass), salt, credentialKeyIter, 32), nil
+	}
+
+	key, err := ioutil.ReadFile(s.KeyPath)
+	if err == nil {
+		if len(key) != 32 {
+			return nil, errors.New(s.KeyPath + ": invalid key")
+		}
+		return key, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// A new key cannot decrypt existing credentials
+	if _, err = os.Stat(s.Path); err == nil {
+		return nil, fmt.Errorf("%s: key not found, set %s or restore %s", s.Path, envCredentialKey, s.KeyPath)
+	}
+
+	key = make([]byte, 32)
+	if _, err = io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.KeyPath), 0700); err != nil {
+		return nil, err
+	}
+
+	return key, ioutil.WriteFile(s.KeyPath, key, 0600)
+}
+
+func (s *FileCredentialStore) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := s.key(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// List returns all stored credentials.
+func (s *FileCredentialStore) List() ([]Credential, error) {
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(b) < credentialSaltSize {
+		return nil, errors.New(s.Path + ": invalid file")
+	}
+
+	salt, b := b[:credentialSaltSize], b[credentialSaltSize:]
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	size := gcm.NonceSize()
+	if len(b) < size {
+		return nil, errors.New(s.Path + ": invalid file")
+	}
+
+	b, err = gcm.Open(nil, b[:size], b[size:], nil)
+	if err != nil {
+		return nil, errors.New(s.Path + ": unable to decrypt, wrong key?")
+	}
+
+	var creds []Credential
+	err = json.Unmarshal(b, &creds)
+	return creds, err
+}
+
+func (s *FileCredentialStore) save(creds []Credential) error {
+	b, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, credentialSaltSize)
+	if _, err = io.ReadFull(rand.Reader, salt); err != nil {
+		return err
+	}
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+
+	data := append(salt, gcm.Seal(nonce, nonce, b, nil)...)
+
+	return ioutil.WriteFile(s.Path, data, 0600)
+}
+
+func (c *Credential) matches(o *Credential) bool {
+	if c.Protocol != o.Protocol || c.Host != o.Host {
+		return false
+	}
+	return o.Username == "" || c.Username == o.Username
+}
+
+func (s *FileCredentialStore) Get(c *Credential) error {
+	creds, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	for _, cred := range creds {
+		if cred.matches(c) {
+			c.Username = cred.Username
+			c.Password = cred.Password
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *FileCredentialStore) Store(c *Credential) error {
+	if c.Username == "" || c.Password == "" {
+		return errors.New("username and password are required")
+	}
+
+	creds, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	var update []Credential
+	for _, cred := range creds {
+		if !cred.matches(c) {
+			update = append(update, cred)
+		}
+	}
+
+	return s.save(append(update, *c))
+}
+
+func (s *FileCredentialStore) Erase(c *Credential) error {
+	creds, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	var update []Credential
+	for _, cred := range creds {
+		if !cred.matches(c) {
+			update = append(update, cred)
+		}
+	}
+
+	if len(update) == len(creds) {
+		return nil
+	}
+
+	return s.save(update)
+}
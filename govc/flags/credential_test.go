@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentialEncoding(t *testing.T) {
+	in := Credential{Protocol: "https", Host: "vc:443", Username: "user", Password: "p=ss"}
+
+	var buf bytes.Buffer
+	if err := in.encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var out Credential
+	if err := out.decode(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if in != out {
+		t.Errorf("%#v != %#v", in, out)
+	}
+
+	in.Password = "bad\nline"
+	if err := in.encode(&buf); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestFileCredentialStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "govc-credential")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &FileCredentialStore{
+		Path:    filepath.Join(dir, "credentials"),
+		KeyPath: filepath.Join(dir, "credentials.key"),
+	}
+
+	c := Credential{Protocol: "https", Host: "vc", Username: "user", Password: "secret"}
+	if err = s.Store(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(b, []byte(c.Password)) {
+		t.Error("password stored in plaintext")
+	}
+
+	get := Credential{Protocol: "https", Host: "vc"}
+	if err = s.Get(&get); err != nil {
+		t.Fatal(err)
+	}
+
+	if get != c {
+		t.Errorf("%#v != %#v", get, c)
+	}
+
+	if err = s.Erase(&Credential{Protocol: "https", Host: "vc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(creds) != 0 {
+		t.Errorf("creds=%d", len(creds))
+	}
+}
+
+func TestPBKDF2(t *testing.T) {
+	// RFC 7914, section 11
+	tests := []struct {
+		password, salt string
+		iter           int
+		key            string
+	}{
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+	}
+
+	for _, test := range tests {
+		key := hex.EncodeToString(pbkdf2([]byte(test.password), []byte(test.salt), test.iter, 64))
+		if key != test.key {
+			t.Errorf("%s: %s", test.password, key)
+		}
+	}
+}
+
+func TestFileCredentialStoreKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "govc-credential")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &FileCredentialStore{
+		Path:    filepath.Join(dir, "credentials"),
+		KeyPath: filepath.Join(dir, "credentials.key"),
+	}
+
+	c := Credential{Protocol: "https", Host: "vc", Username: "user", Password: "secret"}
+	if err = s.Store(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Remove(s.KeyPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// must not replace the key used to encrypt existing credentials
+	if _, err = s.List(); err == nil {
+		t.Error("expected error")
+	}
+
+	if _, err = os.Stat(s.KeyPath); !os.IsNotExist(err) {
+		t.Errorf("key file created: %v", err)
+	}
+
+	if err = os.Remove(s.Path); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv(envCredentialKey, "passphrase")
+	defer os.Unsetenv(envCredentialKey)
+
+	if err = s.Store(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	get := Credential{Protocol: "https", Host: "vc"}
+	if err = s.Get(&get); err != nil {
+		t.Fatal(err)
+	}
+
+	if get != c {
+		t.Errorf("%#v != %#v", get, c)
+	}
+
+	os.Setenv(envCredentialKey, "wrong")
+
+	if _, err = s.List(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestCredentialHelperEmpty(t *testing.T) {
+	for _, helper := range []string{" ", "!", "! \t"} {
+		h := NewCredentialHelper(helper)
+		if err := h.Get(&Credential{Host: "vc"}); err == nil {
+			t.Errorf("%q: expected error", helper)
+		}
+	}
+}
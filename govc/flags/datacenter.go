@@ -19,7 +19,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 
 	"github.com/RotatingFans/govmomi/find"
 	"github.com/RotatingFans/govmomi/object"
@@ -59,7 +58,7 @@ func (flag *DatacenterFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		flag.OutputFlag.Register(ctx, f)
 
 		env := "GOVC_DATACENTER"
-		value := os.Getenv(env)
+		value := getenv(env)
 		usage := fmt.Sprintf("Datacenter [%s]", env)
 		f.StringVar(&flag.path, "dc", value, usage)
 	})
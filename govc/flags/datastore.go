@@ -20,7 +20,6 @@ import (
 	"flag"
 	"fmt"
 	"net/url"
-	"os"
 
 	"github.com/RotatingFans/govmomi/object"
 	"github.com/RotatingFans/govmomi/vim25/types"
@@ -62,7 +61,7 @@ func (f *DatastoreFlag) Register(ctx context.Context, fs *flag.FlagSet) {
 		f.DatacenterFlag.Register(ctx, fs)
 
 		env := "GOVC_DATASTORE"
-		value := os.Getenv(env)
+		value := getenv(env)
 		usage := fmt.Sprintf("Datastore [%s]", env)
 		fs.StringVar(&f.Name, "ds", value, usage)
 	})
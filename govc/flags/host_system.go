@@ -19,7 +19,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 
 	"github.com/RotatingFans/govmomi/object"
 	"golang.org/x/net/context"
@@ -59,7 +58,7 @@ func (flag *HostSystemFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		flag.SearchFlag.Register(ctx, f)
 
 		env := "GOVC_HOST"
-		value := os.Getenv(env)
+		value := getenv(env)
 		usage := fmt.Sprintf("Host system [%s]", env)
 		f.StringVar(&flag.name, "host", value, usage)
 	})
@@ -131,6 +130,22 @@ func (flag *HostSystemFlag) HostSystem() (*object.HostSystem, error) {
 	return flag.host, err
 }
 
+// HostSystemsOrDefault returns the hosts matching args, which can include a cluster path.
+// If args is empty, the host given by the -host or search flags is returned,
+// or the default host if there is only one.
+func (flag *HostSystemFlag) HostSystemsOrDefault(args []string) ([]*object.HostSystem, error) {
+	if len(args) != 0 {
+		return flag.HostSystems(args)
+	}
+
+	host, err := flag.HostSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	return []*object.HostSystem{host}, nil
+}
+
 func (flag *HostSystemFlag) HostNetworkSystem() (*object.HostNetworkSystem, error) {
 	host, err := flag.HostSystem()
 	if err != nil {
@@ -19,7 +19,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 
 	"github.com/RotatingFans/govmomi/object"
 	"github.com/RotatingFans/govmomi/vim25/types"
@@ -56,7 +55,7 @@ func (flag *NetworkFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		flag.DatacenterFlag.Register(ctx, f)
 
 		env := "GOVC_NETWORK"
-		value := os.Getenv(env)
+		value := getenv(env)
 		flag.name = value
 		usage := fmt.Sprintf("Network [%s]", env)
 		f.Var(flag, "net", usage)
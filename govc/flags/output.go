@@ -17,12 +17,16 @@ limitations under the License.
 package flags
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"golang.org/x/net/context"
@@ -39,19 +43,41 @@ type OutputWriter interface {
 type OutputFlag struct {
 	common
 
+	// JSON is true if structured output was requested, via -json, -o or -select.
+	// Commands use it to retrieve all properties rather than those used by the text output.
 	JSON bool
 	TTY  bool
 	Dump bool
+
+	format   string
+	selector string
+
+	sel  *selector
+	tmpl *template.Template
+	csv  []string
+
+	out io.Writer
 }
 
-var outputFlagKey = flagKey("output")
+var (
+	outputFlagKey   = flagKey("output")
+	outputWriterKey = flagKey("output.writer")
+)
+
+// ContextWithOutput returns a context in which new OutputFlags write to w, rather than os.Stdout.
+func ContextWithOutput(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, outputWriterKey, w)
+}
 
 func NewOutputFlag(ctx context.Context) (*OutputFlag, context.Context) {
 	if v := ctx.Value(outputFlagKey); v != nil {
 		return v.(*OutputFlag), ctx
 	}
 
-	v := &OutputFlag{}
+	v := &OutputFlag{out: os.Stdout}
+	if w, ok := ctx.Value(outputWriterKey).(io.Writer); ok {
+		v.out = w
+	}
 	ctx = context.WithValue(ctx, outputFlagKey, v)
 	return v, ctx
 }
@@ -60,11 +86,17 @@ func (flag *OutputFlag) Register(ctx context.Context, f *flag.FlagSet) {
 	flag.RegisterOnce(func() {
 		f.BoolVar(&flag.JSON, "json", false, "Enable JSON output")
 		f.BoolVar(&flag.Dump, "dump", false, "Enable output dump")
+		f.StringVar(&flag.format, "o", "", "Output format: json, yaml, csv[=COLUMN,...] or template=TEMPLATE")
+		f.StringVar(&flag.selector, "select", "", "Select output field, for example: VirtualMachines[*].Name")
 	})
 }
 
 func (flag *OutputFlag) Process(ctx context.Context) error {
 	return flag.ProcessOnce(func() error {
+		if err := flag.processFormat(); err != nil {
+			return err
+		}
+
 		if !flag.JSON {
 			// Assume we have a tty if not outputting JSON
 			flag.TTY = true
@@ -91,8 +123,10 @@ func (flag *OutputFlag) Write(b []byte) (int, error) {
 		return 0, nil
 	}
 
-	n, err := os.Stdout.Write(b)
-	os.Stdout.Sync()
+	n, err := flag.out.Write(b)
+	if f, ok := flag.out.(*os.File); ok {
+		f.Sync()
+	}
 	return n, err
 }
 
@@ -100,11 +134,103 @@ func (flag *OutputFlag) WriteString(s string) (int, error) {
 	return flag.Write([]byte(s))
 }
 
+func (flag *OutputFlag) processFormat() error {
+	if flag.selector != "" {
+		sel, err := parseSelector(flag.selector)
+		if err != nil {
+			return err
+		}
+		flag.sel = sel
+		flag.JSON = true
+	}
+
+	if flag.format == "" {
+		return nil
+	}
+
+	format := strings.SplitN(flag.format, "=", 2)
+
+	switch format[0] {
+	case "json", "yaml":
+	case "csv":
+		if len(format) == 2 && format[1] != "" {
+			flag.csv = strings.Split(format[1], ",")
+		}
+	case "template":
+		if len(format) != 2 {
+			return errors.New("-o template requires a template, for example: -o 'template={{.Name}}'")
+		}
+		tmpl, err := template.New("output").Parse(format[1])
+		if err != nil {
+			return err
+		}
+		flag.tmpl = tmpl
+	default:
+		return fmt.Errorf("unsupported output format: %s", flag.format)
+	}
+
+	flag.format = format[0]
+	flag.JSON = true
+
+	return nil
+}
+
+func (flag *OutputFlag) writeFormat(out io.Writer, result OutputWriter) error {
+	var data interface{} = result
+
+	if flag.sel != nil || flag.format == "yaml" || flag.format == "csv" {
+		v, err := toJSONValue(result)
+		if err != nil {
+			return err
+		}
+
+		if flag.sel != nil {
+			if v, err = flag.sel.Select(v); err != nil {
+				return err
+			}
+		}
+
+		data = v
+	}
+
+	switch flag.format {
+	case "":
+		return writeSelected(out, data, flag.sel.wildcard)
+	case "json":
+		if flag.sel != nil {
+			data = toTemplateValue(data)
+		}
+		return json.NewEncoder(out).Encode(data)
+	case "yaml":
+		return writeYAMLValue(out, data)
+	case "csv":
+		return writeCSV(out, data, flag.csv)
+	case "template":
+		if flag.sel != nil {
+			data = toTemplateValue(data)
+		}
+		var buf bytes.Buffer
+		if err := flag.tmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+		s := buf.String()
+		if !strings.HasSuffix(s, "\n") {
+			s += "\n"
+		}
+		_, err := io.WriteString(out, s)
+		return err
+	}
+
+	return nil
+}
+
 func (flag *OutputFlag) WriteResult(result OutputWriter) error {
 	var err error
-	var out = os.Stdout
+	var out = flag.out
 
-	if flag.JSON {
+	if flag.format != "" || flag.sel != nil {
+		err = flag.writeFormat(out, result)
+	} else if flag.JSON {
 		err = json.NewEncoder(out).Encode(result)
 	} else if flag.Dump {
 		scs := spew.ConfigState{Indent: "    "}
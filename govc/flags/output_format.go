@@ -0,0 +1,452 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The output formats below operate on the JSON encoding of a result,
+// so they apply to any OutputWriter without changes to the result types.
+// Objects are decoded with their field order preserved.
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+type jsonObject []field
+
+func (o jsonObject) get(key string) (interface{}, bool) {
+	for _, f := range o {
+		if f.key == key {
+			return f.value, true
+		}
+	}
+	return nil, false
+}
+
+// toJSONValue returns the JSON encoding of v, decoded as jsonObject, []interface{} or a scalar.
+func toJSONValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	return decodeJSONValue(dec)
+}
+
+func decodeJSONValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := jsonObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			val, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, field{key.(string), val})
+		}
+		_, err = dec.Token()
+		return obj, err
+	case '[':
+		arr := []interface{}{}
+		for dec.More() {
+			val, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		_, err = dec.Token()
+		return arr, err
+	}
+
+	return nil, fmt.Errorf("unexpected JSON delimiter: %s", delim)
+}
+
+// toTemplateValue converts a decoded JSON value to maps and slices for use with text/template.
+func toTemplateValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case jsonObject:
+		m := make(map[string]interface{}, len(v))
+		for _, f := range v {
+			m[f.key] = toTemplateValue(f.value)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i := range v {
+			a[i] = toTemplateValue(v[i])
+		}
+		return a
+	}
+	return v
+}
+
+func scalarString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case jsonObject, []interface{}:
+		return false
+	}
+	return true
+}
+
+// selector is a JSON path like expression, for example: "VirtualMachines[*].Name",
+// "VirtualMachines.0.Summary" or "Datastores[0].Summary.FreeSpace".
+// The wildcard "*" matches all elements of an array or all fields of an object.
+type selector struct {
+	path     []string
+	wildcard bool
+}
+
+func parseSelector(s string) (*selector, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "$"), ".")
+
+	sel := &selector{}
+	var name bytes.Buffer
+
+	flush := func() {
+		if name.Len() != 0 {
+			sel.path = append(sel.path, name.String())
+			name.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid selector %q: missing ']'", s)
+			}
+			sel.path = append(sel.path, s[i+1:i+end])
+			i += end
+		default:
+			name.WriteByte(c)
+		}
+	}
+	flush()
+
+	for _, p := range sel.path {
+		if p == "*" {
+			sel.wildcard = true
+		}
+	}
+
+	return sel, nil
+}
+
+// Select returns the value matching the selector.  If the selector contains a wildcard,
+// the matches are returned as an array.
+func (s *selector) Select(v interface{}) (interface{}, error) {
+	values := []interface{}{v}
+
+	for _, p := range s.path {
+		var next []interface{}
+
+		for _, v := range values {
+			switch v := v.(type) {
+			case jsonObject:
+				if p == "*" {
+					for _, f := range v {
+						next = append(next, f.value)
+					}
+				} else if val, ok := v.get(p); ok {
+					next = append(next, val)
+				}
+			case []interface{}:
+				if p == "*" {
+					next = append(next, v...)
+				} else if i, err := strconv.Atoi(p); err == nil {
+					if i < 0 {
+						i += len(v)
+					}
+					if i >= 0 && i < len(v) {
+						next = append(next, v[i])
+					}
+				}
+			}
+		}
+
+		values = next
+	}
+
+	if s.wildcard {
+		if values == nil {
+			values = []interface{}{}
+		}
+		return values, nil
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no value matches %q", strings.Join(s.path, "."))
+	}
+
+	return values[0], nil
+}
+
+// writeSelected writes scalar values one per line, and others as JSON.
+func writeSelected(w io.Writer, v interface{}, wildcard bool) error {
+	values := []interface{}{v}
+	if wildcard {
+		values = v.([]interface{})
+	}
+
+	for _, v := range values {
+		if isScalar(v) {
+			if _, err := fmt.Fprintln(w, scalarString(v)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := json.NewEncoder(w).Encode(toTemplateValue(v)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "", "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return strconv.Quote(s)
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@` \t") ||
+		strings.ContainsAny(s, "\n\r\t") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") ||
+		strings.HasSuffix(s, ":") || strings.HasSuffix(s, " ") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return yamlString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func writeYAML(w *bytes.Buffer, v interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	switch v := v.(type) {
+	case jsonObject:
+		if len(v) == 0 {
+			fmt.Fprintf(w, "%s{}\n", pad)
+			return
+		}
+		for _, f := range v {
+			key := yamlString(f.key)
+			switch val := f.value.(type) {
+			case jsonObject:
+				if len(val) == 0 {
+					fmt.Fprintf(w, "%s%s: {}\n", pad, key)
+					continue
+				}
+			case []interface{}:
+				if len(val) == 0 {
+					fmt.Fprintf(w, "%s%s: []\n", pad, key)
+					continue
+				}
+			default:
+				fmt.Fprintf(w, "%s%s: %s\n", pad, key, yamlScalar(val))
+				continue
+			}
+			fmt.Fprintf(w, "%s%s:\n", pad, key)
+			writeYAML(w, f.value, indent+2)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			fmt.Fprintf(w, "%s[]\n", pad)
+			return
+		}
+		for _, item := range v {
+			if isScalar(item) {
+				fmt.Fprintf(w, "%s- %s\n", pad, yamlScalar(item))
+				continue
+			}
+			// Render the item indented, then replace the indent of its first line with "- "
+			var buf bytes.Buffer
+			writeYAML(&buf, item, indent+2)
+			b := buf.Bytes()
+			w.WriteString(pad + "- ")
+			w.Write(b[indent+2:])
+		}
+	default:
+		fmt.Fprintf(w, "%s%s\n", pad, yamlScalar(v))
+	}
+}
+
+func writeYAMLValue(w io.Writer, v interface{}) error {
+	var buf bytes.Buffer
+	writeYAML(&buf, v, 0)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func flatten(prefix string, v interface{}, row jsonObject) jsonObject {
+	switch v := v.(type) {
+	case jsonObject:
+		for _, f := range v {
+			row = flatten(prefix+f.key+".", f.value, row)
+		}
+	case []interface{}:
+		for i, item := range v {
+			row = flatten(prefix+strconv.Itoa(i)+".", item, row)
+		}
+	case nil:
+		// A null value (such as a nil slice) has no columns of its own,
+		// its cell is left empty if the column is added by another row.
+	default:
+		row = append(row, field{strings.TrimSuffix(prefix, "."), v})
+	}
+	return row
+}
+
+// writeCSV writes the rows of v as CSV, with nested fields flattened into dot separated columns.
+// If v is an object with a single array field, such as most govc results, the array elements are the rows.
+// If columns is not empty, only the given columns (or columns prefixed with "column.") are written.
+func writeCSV(w io.Writer, v interface{}, columns []string) error {
+	if obj, ok := v.(jsonObject); ok && len(obj) == 1 {
+		if _, ok := obj[0].value.([]interface{}); ok {
+			v = obj[0].value
+		}
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+
+	var rows []jsonObject
+	var header []string
+	seen := make(map[string]bool)
+
+	match := func(key string) bool {
+		if len(columns) == 0 {
+			return true
+		}
+		for _, c := range columns {
+			if key == c || strings.HasPrefix(key, c+".") {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, item := range items {
+		prefix := ""
+		if isScalar(item) {
+			prefix = "Value."
+		}
+		row := flatten(prefix, item, nil)
+		rows = append(rows, row)
+
+		for _, f := range row {
+			if !seen[f.key] && match(f.key) {
+				seen[f.key] = true
+				header = append(header, f.key)
+			}
+		}
+	}
+
+	if len(columns) != 0 {
+		// Order columns as requested
+		var ordered []string
+		added := make(map[string]bool)
+		for _, c := range columns {
+			for _, h := range header {
+				if !added[h] && (h == c || strings.HasPrefix(h, c+".")) {
+					added[h] = true
+					ordered = append(ordered, h)
+				}
+			}
+		}
+		header = ordered
+	}
+
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(header))
+		for i, h := range header {
+			val, _ := row.get(h)
+			record[i] = scalarString(val)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
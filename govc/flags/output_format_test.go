@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testResult struct {
+	Items []testItem
+}
+
+type testItem struct {
+	Name   string
+	Size   int
+	Tags   []string
+	Config struct {
+		Enabled bool
+	}
+}
+
+func newTestResult() testResult {
+	r := testResult{
+		Items: []testItem{
+			{Name: "vm1", Size: 1, Tags: []string{"a", "b"}},
+			{Name: "yes", Size: 2},
+		},
+	}
+	r.Items[0].Config.Enabled = true
+	return r
+}
+
+func TestOutputSelect(t *testing.T) {
+	v, err := toJSONValue(newTestResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sel    string
+		expect string
+	}{
+		{"Items[*].Name", "vm1\nyes\n"},
+		{".Items.1.Size", "2\n"},
+		{"Items[-1].Name", "yes\n"},
+		{"Items[0].Tags", "[\"a\",\"b\"]\n"},
+	}
+
+	for _, test := range tests {
+		s, err := parseSelector(test.sel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		val, err := s.Select(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err = writeSelected(&buf, val, s.wildcard); err != nil {
+			t.Fatal(err)
+		}
+
+		if buf.String() != test.expect {
+			t.Errorf("%s: %q", test.sel, buf.String())
+		}
+	}
+
+	s, _ := parseSelector("Items[0].Missing")
+	if _, err := s.Select(v); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestOutputYAML(t *testing.T) {
+	v, err := toJSONValue(newTestResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = writeYAMLValue(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `Items:
+  - Name: vm1
+    Size: 1
+    Tags:
+      - a
+      - b
+    Config:
+      Enabled: true
+  - Name: "yes"
+    Size: 2
+    Tags: null
+    Config:
+      Enabled: false
+`
+
+	if buf.String() != expect {
+		t.Errorf("yaml:\n%s", buf.String())
+	}
+}
+
+func TestOutputCSV(t *testing.T) {
+	v, err := toJSONValue(newTestResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = writeCSV(&buf, v, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := "Name,Size,Tags.0,Tags.1,Config.Enabled\nvm1,1,a,b,true\nyes,2,,,false\n"
+	if buf.String() != expect {
+		t.Errorf("csv: %q", buf.String())
+	}
+
+	buf.Reset()
+	if err = writeCSV(&buf, v, []string{"Config", "Name"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expect = "Config.Enabled,Name\ntrue,vm1\nfalse,yes\n"
+	if buf.String() != expect {
+		t.Errorf("csv: %q", buf.String())
+	}
+}
@@ -19,7 +19,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 
 	"github.com/RotatingFans/govmomi/object"
 	"golang.org/x/net/context"
@@ -52,7 +51,7 @@ func (flag *ResourcePoolFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		flag.DatacenterFlag.Register(ctx, f)
 
 		env := "GOVC_RESOURCE_POOL"
-		value := os.Getenv(env)
+		value := getenv(env)
 		usage := fmt.Sprintf("Resource pool [%s]", env)
 		f.StringVar(&flag.name, "pool", value, usage)
 	})
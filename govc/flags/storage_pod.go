@@ -3,7 +3,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 
 	"golang.org/x/net/context"
 
@@ -38,7 +37,7 @@ func (f *StoragePodFlag) Register(ctx context.Context, fs *flag.FlagSet) {
 		f.DatacenterFlag.Register(ctx, fs)
 
 		env := "GOVC_DATASTORE_CLUSTER"
-		value := os.Getenv(env)
+		value := getenv(env)
 		usage := fmt.Sprintf("Datastore cluster [%s]", env)
 		fs.StringVar(&f.Name, "datastore-cluster", value, usage)
 	})
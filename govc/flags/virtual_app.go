@@ -19,7 +19,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 
 	"github.com/RotatingFans/govmomi/object"
 	"golang.org/x/net/context"
@@ -55,7 +54,7 @@ func (flag *VirtualAppFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		flag.SearchFlag.Register(ctx, f)
 
 		env := "GOVC_VAPP"
-		value := os.Getenv(env)
+		value := getenv(env)
 		usage := fmt.Sprintf("Virtual App [%s]", env)
 		f.StringVar(&flag.name, "vapp", value, usage)
 	})
@@ -19,7 +19,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 
 	"github.com/RotatingFans/govmomi/object"
 	"golang.org/x/net/context"
@@ -58,7 +57,7 @@ func (flag *VirtualMachineFlag) Register(ctx context.Context, f *flag.FlagSet) {
 		flag.SearchFlag.Register(ctx, f)
 
 		env := "GOVC_VM"
-		value := os.Getenv(env)
+		value := getenv(env)
 		usage := fmt.Sprintf("Virtual machine [%s]", env)
 		f.StringVar(&flag.name, "vm", value, usage)
 	})
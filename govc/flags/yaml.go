@@ -0,0 +1,349 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// DecodeYAML decodes the YAML document read from r into v, using the JSON
+// encoding rules for v.  Only the block style subset written by the '-o yaml'
+// output format is supported: mappings, sequences, scalars and single line flow
+// sequences.  Input that starts with '{' or '[' is decoded as JSON.
+// Numbers decoded into interface{} values are json.Number, keeping the text of the number.
+func DecodeYAML(r io.Reader, v interface{}) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		val, err := parseYAML(b)
+		if err != nil {
+			return err
+		}
+
+		if trimmed, err = json.Marshal(val); err != nil {
+			return err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+
+	return dec.Decode(v)
+}
+
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(b []byte) (interface{}, error) {
+	var p yamlParser
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	num := 0
+
+	for scanner.Scan() {
+		num++
+		line := strings.TrimRight(stripYAMLComment(scanner.Text()), " \t\r")
+		text := strings.TrimLeft(line, " ")
+
+		if text == "" || text == "---" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", num)
+		}
+
+		p.lines = append(p.lines, yamlLine{num, len(line) - len(text), text})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+
+	val, err := p.parseBlock(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+
+	return val, nil
+}
+
+// stripYAMLComment removes a trailing comment, ignoring '#' within quotes.
+func stripYAMLComment(s string) string {
+	var quote byte
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++ // skip escaped char
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.IndexByte(" :-[,{", s[i-1]) >= 0 {
+				quote = c
+			}
+		case c == '#':
+			if i == 0 || s[i-1] == ' ' || s[i-1] == '\t' {
+				return s[:i]
+			}
+		}
+	}
+
+	return s
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	num := 0
+	if p.pos < len(p.lines) {
+		num = p.lines[p.pos].num
+	}
+	return fmt.Errorf("yaml: line %d: %s", num, fmt.Sprintf(format, args...))
+}
+
+func isYAMLSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	if isYAMLSeqItem(p.lines[p.pos].text) {
+		return p.parseSeq(indent)
+	}
+
+	if _, _, ok := splitYAMLKey(p.lines[p.pos].text); ok {
+		return p.parseMap(indent)
+	}
+
+	line := p.lines[p.pos]
+	p.pos++
+	return parseYAMLScalar(line.text)
+}
+
+func (p *yamlParser) parseMap(indent int) (interface{}, error) {
+	m := make(map[string]interface{})
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if isYAMLSeqItem(line.text) {
+			break
+		}
+
+		key, value, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, p.errorf("expected a mapping key")
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		p.pos++
+
+		if value != "" {
+			val, err := parseYAMLScalar(value)
+			if err != nil {
+				return nil, fmt.Errorf("yaml: line %d: %s", line.num, err)
+			}
+			m[key] = val
+			continue
+		}
+
+		// A nested block is either indented, or a sequence at the same indentation
+		var val interface{}
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent || (next.indent == indent && isYAMLSeqItem(next.text)) {
+				var err error
+				if val, err = p.parseBlock(next.indent); err != nil {
+					return nil, err
+				}
+			}
+		}
+		m[key] = val
+	}
+
+	return m, nil
+}
+
+func (p *yamlParser) parseSeq(indent int) (interface{}, error) {
+	seq := []interface{}{}
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !isYAMLSeqItem(line.text) {
+			if line.indent > indent {
+				return nil, p.errorf("unexpected indentation")
+			}
+			break
+		}
+
+		item := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+
+		if item == "" {
+			p.pos++
+			var val interface{}
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				var err error
+				if val, err = p.parseBlock(p.lines[p.pos].indent); err != nil {
+					return nil, err
+				}
+			}
+			seq = append(seq, val)
+			continue
+		}
+
+		if _, _, ok := splitYAMLKey(item); ok || isYAMLSeqItem(item) {
+			// The item is a nested block starting on this line, reparse it at its own indentation
+			offset := len(line.text) - len(item)
+			p.lines[p.pos] = yamlLine{line.num, indent + offset, item}
+
+			val, err := p.parseBlock(indent + offset)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, val)
+			continue
+		}
+
+		val, err := parseYAMLScalar(item)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %s", line.num, err)
+		}
+		seq = append(seq, val)
+		p.pos++
+	}
+
+	return seq, nil
+}
+
+// splitYAMLKey splits a "key: value" mapping entry.
+func splitYAMLKey(text string) (string, string, bool) {
+	if text[0] == '"' || text[0] == '\'' {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", "", false
+		}
+		rest := text[end+2:]
+		if rest != ":" && !strings.HasPrefix(rest, ": ") {
+			return "", "", false
+		}
+		key, err := parseYAMLScalar(text[:end+2])
+		if err != nil {
+			return "", "", false
+		}
+		return fmt.Sprint(key), strings.TrimSpace(rest[1:]), true
+	}
+
+	if strings.HasSuffix(text, ":") && !strings.Contains(text, ": ") {
+		return strings.TrimSuffix(text, ":"), "", true
+	}
+
+	i := strings.Index(text, ": ")
+	if i <= 0 || strings.ContainsAny(text[:1], "[{") {
+		return "", "", false
+	}
+
+	return text[:i], strings.TrimSpace(text[i+2:]), true
+}
+
+func parseYAMLScalar(s string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		return strconv.Unquote(s)
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return nil, fmt.Errorf("unterminated string %s", s)
+		}
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1), nil
+	case strings.HasPrefix(s, "["):
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("unterminated sequence %s", s)
+		}
+		seq := []interface{}{}
+		body := strings.TrimSpace(s[1 : len(s)-1])
+		if body == "" {
+			return seq, nil
+		}
+		for _, item := range strings.Split(body, ",") {
+			val, err := parseYAMLScalar(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, val)
+		}
+		return seq, nil
+	case s == "{}":
+		return map[string]interface{}{}, nil
+	case s == "|" || s == ">" || strings.HasPrefix(s, "{") || strings.HasPrefix(s, "&") || strings.HasPrefix(s, "*"):
+		return nil, fmt.Errorf("unsupported value %s", s)
+	}
+
+	switch s {
+	case "~", "null":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return json.Number(strconv.FormatInt(i, 10)), nil
+	}
+
+	// Other numbers are kept as written, if valid in JSON, otherwise the value is a string
+	var n json.Number
+	if err := json.Unmarshal([]byte(s), &n); err == nil {
+		return n, nil
+	}
+
+	return s, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	doc := `
+# VM spec
+name: web-01
+cpus: 2
+ratio: 0.5
+big: 1.50e3
+padded: 007
+text: inf
+template: false
+annotation: "web server: # not a comment"
+notes: it's here # comment
+empty:
+tags: [a, "b", 3]
+disks:
+  - size: 10GB
+    thick: true
+  - size: 20GB
+nics:
+- network: VM Network
+  adapter: vmxnet3
+extraConfig:
+  guestinfo.role: 'frontend'
+  "quoted:key": ~
+matrix:
+  -
+    - 1
+    - 2
+  - - 3
+`
+	var v map[string]interface{}
+	if err := DecodeYAML(strings.NewReader(doc), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]interface{}{
+		"name":       "web-01",
+		"cpus":       json.Number("2"),
+		"ratio":      json.Number("0.5"),
+		"big":        json.Number("1.50e3"),
+		"padded":     json.Number("7"),
+		"text":       "inf",
+		"template":   false,
+		"annotation": "web server: # not a comment",
+		"notes":      "it's here",
+		"empty":      nil,
+		"tags":       []interface{}{"a", "b", json.Number("3")},
+		"disks": []interface{}{
+			map[string]interface{}{"size": "10GB", "thick": true},
+			map[string]interface{}{"size": "20GB"},
+		},
+		"nics": []interface{}{
+			map[string]interface{}{"network": "VM Network", "adapter": "vmxnet3"},
+		},
+		"extraConfig": map[string]interface{}{
+			"guestinfo.role": "frontend",
+			"quoted:key":     nil,
+		},
+		"matrix": []interface{}{
+			[]interface{}{json.Number("1"), json.Number("2")},
+			[]interface{}{json.Number("3")},
+		},
+	}
+
+	if !reflect.DeepEqual(v, expect) {
+		t.Errorf("got %#v", v)
+	}
+}
+
+func TestDecodeYAMLRoundTrip(t *testing.T) {
+	r := newTestResult()
+
+	v, err := toJSONValue(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	writeYAML(&buf, v, 0)
+
+	var out testResult
+	if err = DecodeYAML(&buf, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(out, r) {
+		t.Errorf("got %#v", out)
+	}
+}
+
+func TestDecodeYAMLErrors(t *testing.T) {
+	tests := []string{
+		"a: 1\n  b: 2\n",
+		"a: 1\na: 2\n",
+		"a: |\n  text\n",
+		"a: \"open\n",
+	}
+
+	for _, test := range tests {
+		var v interface{}
+		if err := DecodeYAML(strings.NewReader(test), &v); err == nil {
+			t.Errorf("expected error for %q", test)
+		}
+	}
+}
+
+func TestDecodeYAMLScalars(t *testing.T) {
+	var spec struct {
+		ExtraConfig map[string]interface{} `json:"extraConfig"`
+	}
+
+	for _, doc := range []string{
+		"extraConfig:\n  disk.EnableUUID: true\n  numa.nodeAffinity: 0\n  ratio: 1.50\n",
+		`{"extraConfig": {"disk.EnableUUID": true, "numa.nodeAffinity": 0, "ratio": 1.50}}`,
+	} {
+		if err := DecodeYAML(strings.NewReader(doc), &spec); err != nil {
+			t.Fatal(err)
+		}
+
+		for key, expect := range map[string]string{
+			"disk.EnableUUID":   "true",
+			"numa.nodeAffinity": "0",
+			"ratio":             "1.50",
+		} {
+			if val := fmt.Sprint(spec.ExtraConfig[key]); val != expect {
+				t.Errorf("%s=%s", key, val)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ad
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type info struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("host.ad.info", &info{})
+}
+
+func (cmd *info) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *info) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *info) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *info) Description() string {
+	return `Display Active Directory domain membership of HOST or all hosts in CLUSTER.
+
+Examples:
+  govc host.ad.info -host hostname
+  govc host.ad.info /dc1/host/cluster1`
+}
+
+type adInfo struct {
+	Host             string   `json:"host"`
+	Enabled          bool     `json:"enabled"`
+	JoinedDomain     string   `json:"joinedDomain"`
+	TrustedDomain    []string `json:"trustedDomain"`
+	MembershipStatus string   `json:"domainMembershipStatus"`
+}
+
+func (cmd *info) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	var res infoResult
+
+	for _, host := range hosts {
+		m, err := host.ConfigManager().AuthenticationManager(ctx)
+		if err != nil {
+			return err
+		}
+
+		ad, err := m.ActiveDirectory(ctx)
+		if err != nil {
+			return err
+		}
+
+		info, err := ad.Info(ctx)
+		if err != nil {
+			return err
+		}
+
+		name, err := host.ObjectName(ctx)
+		if err != nil {
+			return err
+		}
+
+		res = append(res, &adInfo{
+			Host:             name,
+			Enabled:          info.Enabled,
+			JoinedDomain:     info.JoinedDomain,
+			TrustedDomain:    info.TrustedDomain,
+			MembershipStatus: info.DomainMembershipStatus,
+		})
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type infoResult []*adInfo
+
+func (r infoResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Host\tDomain\tStatus\tTrusted domains\n")
+
+	for _, info := range r {
+		domain, status, trusted := "-", "-", "-"
+
+		if info.Enabled {
+			domain = info.JoinedDomain
+			status = info.MembershipStatus
+			if len(info.TrustedDomain) != 0 {
+				trusted = strings.Join(info.TrustedDomain, ",")
+			}
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.Host, domain, status, trusted)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ad
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type join struct {
+	*flags.HostSystemFlag
+
+	username string
+	password string
+	cam      string
+}
+
+func init() {
+	cli.Register("host.ad.join", &join{})
+}
+
+func (cmd *join) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.username, "username", "", "Domain user name")
+	f.StringVar(&cmd.password, "password", "", "Domain user password")
+	f.StringVar(&cmd.cam, "cam", "", "Join using the vSphere Authentication Proxy server at this address")
+}
+
+func (cmd *join) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *join) Usage() string {
+	return "DOMAIN [HOST|CLUSTER]..."
+}
+
+func (cmd *join) Description() string {
+	return `Join HOST or all hosts in CLUSTER to Active Directory DOMAIN.
+
+Either domain credentials or the -cam flag must be specified.
+
+Examples:
+  govc host.ad.join -host hostname -username administrator -password pass example.com
+  govc host.ad.join -cam 10.0.0.10 example.com /dc1/host/cluster1`
+}
+
+func (cmd *join) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	if cmd.cam == "" && cmd.username == "" {
+		return errors.New("specify -username or -cam")
+	}
+
+	domain := f.Arg(0)
+
+	hosts, err := cmd.HostSystemsOrDefault(f.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if err = cmd.join(ctx, host, domain); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cmd *join) join(ctx context.Context, host *object.HostSystem, domain string) error {
+	m, err := host.ConfigManager().AuthenticationManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	ad, err := m.ActiveDirectory(ctx)
+	if err != nil {
+		return err
+	}
+
+	var task *object.Task
+
+	if cmd.cam == "" {
+		task, err = ad.JoinDomain(ctx, domain, cmd.username, cmd.password)
+	} else {
+		task, err = ad.JoinDomainWithCAM(ctx, domain, cmd.cam)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	logger := cmd.ProgressLogger(fmt.Sprintf("%s joining domain %s... ", host.InventoryPath, domain))
+	defer logger.Wait()
+
+	_, err = task.WaitForResult(ctx, logger)
+	return err
+}
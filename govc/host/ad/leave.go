@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ad
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type leave struct {
+	*flags.HostSystemFlag
+
+	force bool
+}
+
+func init() {
+	cli.Register("host.ad.leave", &leave{})
+}
+
+func (cmd *leave) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.force, "f", false, "Leave even if permissions are assigned to domain users or groups")
+}
+
+func (cmd *leave) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *leave) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *leave) Description() string {
+	return `Remove HOST or all hosts in CLUSTER from their Active Directory domain.
+
+Examples:
+  govc host.ad.leave -host hostname
+  govc host.ad.leave -f /dc1/host/cluster1`
+}
+
+func (cmd *leave) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if err = cmd.leave(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cmd *leave) leave(ctx context.Context, host *object.HostSystem) error {
+	m, err := host.ConfigManager().AuthenticationManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	ad, err := m.ActiveDirectory(ctx)
+	if err != nil {
+		return err
+	}
+
+	task, err := ad.LeaveCurrentDomain(ctx, cmd.force)
+	if err != nil {
+		return err
+	}
+
+	logger := cmd.ProgressLogger(fmt.Sprintf("%s leaving domain... ", host.InventoryPath))
+	defer logger.Wait()
+
+	_, err = task.WaitForResult(ctx, logger)
+	return err
+}
@@ -0,0 +1,207 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cert
+
+import (
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type fileList []string
+
+func (l *fileList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *fileList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type importx struct {
+	*flags.HostSystemFlag
+
+	ca  fileList
+	crl fileList
+}
+
+func init() {
+	cli.Register("host.cert.import", &importx{})
+}
+
+func (cmd *importx) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.Var(&cmd.ca, "ca", "Replace trusted CA certificates with those in FILE (can be specified multiple times)")
+	f.Var(&cmd.crl, "crl", "Replace CRLs with those in FILE (can be specified multiple times)")
+}
+
+func (cmd *importx) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *importx) Usage() string {
+	return "[FILE]"
+}
+
+func (cmd *importx) Description() string {
+	return `Install SSL certificate FILE on HOST.
+
+FILE is a PEM encoded certificate signed from a request generated by 'host.cert.csr',
+if FILE is '-' the certificate is read from stdin.  Services using the certificate,
+such as hostd, may need to be restarted for the new certificate to be used.
+
+The '-ca' and '-crl' flags replace the full list of trusted CA certificates and CRLs,
+if only one of them is given the current list of the other is kept.
+
+Examples:
+  govc host.cert.import -host hostname hostname.crt
+  govc host.cert.import -host hostname - < hostname.crt
+  govc host.cert.import -host hostname -ca root.pem -ca intermediate.pem
+  govc host.cert.import -host hostname -ca ca.pem -crl ca.crl hostname.crt`
+}
+
+func readFile(name string) (string, error) {
+	var b []byte
+	var err error
+
+	if name == "-" {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(name)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// readPEM returns the PEM blocks of the given type in the given files.
+func readPEM(files []string, kind string) ([]string, error) {
+	var blocks []string
+
+	for _, name := range files {
+		s, err := readFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		rest := []byte(s)
+		n := 0
+
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+
+			if block.Type != kind {
+				continue
+			}
+
+			blocks = append(blocks, string(pem.EncodeToMemory(block)))
+			n++
+		}
+
+		if n == 0 {
+			return nil, fmt.Errorf("%s: no PEM encoded %s found", name, strings.ToLower(kind))
+		}
+	}
+
+	return blocks, nil
+}
+
+func (cmd *importx) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() > 1 || (f.NArg() == 0 && len(cmd.ca) == 0 && len(cmd.crl) == 0) {
+		return flag.ErrHelp
+	}
+
+	var cert string
+
+	if f.NArg() == 1 {
+		s, err := readFile(f.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		if _, err = parseCertificate(s); err != nil {
+			return fmt.Errorf("%s: %s", f.Arg(0), err)
+		}
+
+		cert = s
+	}
+
+	certs, err := readPEM(cmd.ca, "CERTIFICATE")
+	if err != nil {
+		return err
+	}
+
+	crls, err := readPEM(cmd.crl, "X509 CRL")
+	if err != nil {
+		return err
+	}
+
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	m, err := host.ConfigManager().CertificateManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(certs) != 0 || len(crls) != 0 {
+		// Both lists are replaced, keep the current list of the one that was not given
+		if len(certs) == 0 {
+			if certs, err = m.ListCACertificates(ctx); err != nil {
+				return err
+			}
+		}
+
+		if len(crls) == 0 {
+			if crls, err = m.ListCACertificateRevocationLists(ctx); err != nil {
+				return err
+			}
+		}
+
+		if err = m.ReplaceCACertificatesAndCRLs(ctx, certs, crls); err != nil {
+			return err
+		}
+	}
+
+	if cert != "" {
+		return m.InstallServerCertificate(ctx, cert)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cert
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type info struct {
+	*flags.HostSystemFlag
+
+	ca bool
+}
+
+func init() {
+	cli.Register("host.cert.info", &info{})
+}
+
+func (cmd *info) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.ca, "ca", false, "List trusted CA certificates")
+}
+
+func (cmd *info) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *info) Description() string {
+	return `Display SSL certificate info for HOST.
+
+Examples:
+  govc host.cert.info -host hostname
+  govc host.cert.info -host hostname -json | jq -r .NotAfter
+  govc host.cert.info -host hostname -ca`
+}
+
+func (cmd *info) Run(ctx context.Context, f *flag.FlagSet) error {
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	m, err := host.ConfigManager().CertificateManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cmd.ca {
+		certs, err := m.ListCACertificates(ctx)
+		if err != nil {
+			return err
+		}
+
+		var res caResult
+		for _, c := range certs {
+			cert, err := parseCertificate(c)
+			if err != nil {
+				return err
+			}
+
+			res = append(res, certInfo(cert))
+		}
+
+		return cmd.WriteResult(res)
+	}
+
+	info, err := m.CertificateInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cmd.WriteResult(&infoResult{info})
+}
+
+// parseCertificate decodes the first certificate in a PEM block.
+func parseCertificate(s string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed to decode PEM certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func certInfo(cert *x509.Certificate) types.HostCertificateManagerCertificateInfo {
+	notBefore, notAfter := cert.NotBefore, cert.NotAfter
+
+	return types.HostCertificateManagerCertificateInfo{
+		Subject:   cert.Subject.CommonName,
+		Issuer:    cert.Issuer.CommonName,
+		NotBefore: &notBefore,
+		NotAfter:  &notAfter,
+	}
+}
+
+func expires(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	days := int(t.Sub(time.Now()).Hours() / 24)
+
+	if days < 0 {
+		return fmt.Sprintf("%s (expired)", t.Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf("%s (%d days)", t.Format(time.RFC3339), days)
+}
+
+func date(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+type infoResult struct {
+	*types.HostCertificateManagerCertificateInfo
+}
+
+func (r *infoResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Date issued:\t%s\n", date(r.NotBefore))
+	fmt.Fprintf(tw, "Expires:\t%s\n", expires(r.NotAfter))
+	fmt.Fprintf(tw, "Subject:\t%s\n", r.Subject)
+	fmt.Fprintf(tw, "Issuer:\t%s\n", r.Issuer)
+	fmt.Fprintf(tw, "Status:\t%s\n", r.Status)
+
+	return tw.Flush()
+}
+
+type caResult []types.HostCertificateManagerCertificateInfo
+
+func (r caResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Subject\tIssuer\tExpires\n")
+
+	for _, c := range r {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Subject, c.Issuer, expires(c.NotAfter))
+	}
+
+	return tw.Flush()
+}
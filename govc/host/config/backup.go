@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/progress"
+	"github.com/RotatingFans/govmomi/vim25/soap"
+	"golang.org/x/net/context"
+)
+
+type backup struct {
+	*flags.HostSystemFlag
+}
+
+func init() {
+	cli.Register("host.config.backup", &backup{})
+}
+
+func (cmd *backup) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+}
+
+func (cmd *backup) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *backup) Usage() string {
+	return "[FILE]"
+}
+
+func (cmd *backup) Description() string {
+	return `Backup host configuration to FILE.
+
+FILE defaults to configBundle-HOST.tgz in the current directory.
+If FILE is "-", the bundle is written to stdout.
+
+Examples:
+  govc host.config.backup -host hostname
+  govc host.config.backup -host hostname /backups/hostname.tgz`
+}
+
+func (cmd *backup) Run(ctx context.Context, f *flag.FlagSet) (err error) {
+	if f.NArg() > 1 {
+		return flag.ErrHelp
+	}
+
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	fs, err := host.ConfigManager().FirmwareSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := f.Arg(0)
+	if name == "" {
+		hostname, err := host.ObjectName(ctx)
+		if err != nil {
+			return err
+		}
+		name = fmt.Sprintf("configBundle-%s.tgz", hostname)
+	}
+
+	rc, size, err := fs.Backup(ctx, &soap.DefaultDownload)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	var r io.Reader = rc
+	var w io.Writer = os.Stdout
+
+	if name != "-" {
+		file, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+
+		if cmd.OutputFlag.TTY {
+			logger := cmd.ProgressLogger(fmt.Sprintf("Downloading %s... ", name))
+			defer logger.Wait()
+
+			pr := progress.NewReader(logger, r, size)
+			r = pr
+
+			// Mark progress reader as done when returning from this function.
+			defer func() {
+				pr.Done(err)
+			}()
+		}
+	}
+
+	_, err = io.Copy(w, r)
+	return err
+}
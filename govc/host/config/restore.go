@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/soap"
+	"golang.org/x/net/context"
+)
+
+type restore struct {
+	*flags.HostSystemFlag
+
+	force bool
+}
+
+func init() {
+	cli.Register("host.config.restore", &restore{})
+}
+
+func (cmd *restore) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.force, "force", false, "Restore even if the bundle was created with a different build")
+}
+
+func (cmd *restore) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *restore) Usage() string {
+	return "FILE"
+}
+
+func (cmd *restore) Description() string {
+	return `Restore host configuration from FILE, as created by host.config.backup.
+
+The host must be in maintenance mode and is rebooted once the configuration is applied.
+
+Examples:
+  govc host.maintenance.enter -host hostname
+  govc host.config.restore -host hostname configBundle-hostname.tgz`
+}
+
+func (cmd *restore) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	name := f.Arg(0)
+
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	fs, err := host.ConfigManager().FirmwareSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	p := soap.DefaultUpload
+	if cmd.OutputFlag.TTY {
+		logger := cmd.ProgressLogger(fmt.Sprintf("Uploading %s... ", name))
+		p.Progress = logger
+		defer logger.Wait()
+	}
+
+	return fs.Restore(ctx, file, cmd.force, &p)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package date
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+const ntpd = "ntpd"
+
+type serverList []string
+
+func (l *serverList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *serverList) Set(value string) error {
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
+
+type change struct {
+	*flags.ClientFlag
+	*flags.HostSystemFlag
+
+	date    string
+	tz      string
+	servers serverList
+	ntpd    string
+}
+
+func init() {
+	cli.Register("host.date.change", &change{})
+}
+
+func (cmd *change) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.date, "date", "", "Update the host clock, 'now' for the client clock or an RFC3339 date")
+	f.StringVar(&cmd.tz, "tz", "", "Change the time zone, see 'host.date.info -tz' for keys")
+	f.Var(&cmd.servers, "server", "NTP servers (can be specified multiple times or comma separated)")
+	f.StringVar(&cmd.ntpd, "ntpd", "", "Change the NTP service policy and state (on|off)")
+}
+
+func (cmd *change) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *change) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *change) Description() string {
+	return `Change date and time settings of HOST or all hosts in CLUSTER.
+
+When the NTP servers are changed, the NTP service is restarted if it is running.
+
+Examples:
+  govc host.date.change -date now -host hostname
+  govc host.date.change -server 0.pool.ntp.org -server 1.pool.ntp.org -ntpd on /dc1/host/cluster1
+  govc host.date.change -date 2016-08-01T12:00:00Z -host hostname
+  govc host.date.change -ntpd off -host hostname`
+}
+
+func (cmd *change) Run(ctx context.Context, f *flag.FlagSet) error {
+	var date *time.Time
+
+	switch cmd.date {
+	case "", "now":
+	default:
+		d, err := time.Parse(time.RFC3339, cmd.date)
+		if err != nil {
+			return err
+		}
+		date = &d
+	}
+
+	switch cmd.ntpd {
+	case "", "on", "off":
+	default:
+		return fmt.Errorf("invalid -ntpd value: %s", cmd.ntpd)
+	}
+
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if err = cmd.change(ctx, host, date); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cmd *change) change(ctx context.Context, host *object.HostSystem, date *time.Time) error {
+	s, err := host.ConfigManager().DateTimeSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cmd.tz != "" || len(cmd.servers) != 0 {
+		var config types.HostDateTimeConfig
+
+		config.TimeZone = cmd.tz
+
+		if len(cmd.servers) != 0 {
+			config.NtpConfig = &types.HostNtpConfig{Server: cmd.servers}
+		}
+
+		if err = s.UpdateConfig(ctx, config); err != nil {
+			return err
+		}
+	}
+
+	if cmd.date != "" {
+		now := time.Now()
+		if date != nil {
+			now = *date
+		}
+
+		if err = s.Update(ctx, now.UTC()); err != nil {
+			return err
+		}
+	}
+
+	if cmd.ntpd == "" && len(cmd.servers) == 0 {
+		return nil
+	}
+
+	ss, err := host.ConfigManager().ServiceSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.ntpd {
+	case "on":
+		if err = ss.UpdatePolicy(ctx, ntpd, string(types.HostServicePolicyOn)); err != nil {
+			return err
+		}
+	case "off":
+		if err = ss.UpdatePolicy(ctx, ntpd, string(types.HostServicePolicyOff)); err != nil {
+			return err
+		}
+		return ss.Stop(ctx, ntpd)
+	}
+
+	services, err := ss.Service(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		if service.Key != ntpd {
+			continue
+		}
+
+		switch {
+		case service.Running && len(cmd.servers) != 0:
+			return ss.Restart(ctx, ntpd)
+		case !service.Running && cmd.ntpd == "on":
+			return ss.Start(ctx, ntpd)
+		}
+	}
+
+	return nil
+}
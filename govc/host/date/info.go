@@ -0,0 +1,242 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package date
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type info struct {
+	*flags.ClientFlag
+	*flags.OutputFlag
+	*flags.HostSystemFlag
+
+	tz      bool
+	maxSkew time.Duration
+}
+
+func init() {
+	cli.Register("host.date.info", &info{})
+}
+
+func (cmd *info) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.tz, "tz", false, "List available time zones")
+	f.DurationVar(&cmd.maxSkew, "max-skew", 0, "Fail if the clock skew of any host exceeds this duration")
+}
+
+func (cmd *info) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *info) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *info) Description() string {
+	return `Display date and time info for HOST or all hosts in CLUSTER.
+
+The skew is the difference between the host clock and the govc client clock,
+a positive skew means the host clock is ahead.
+
+Examples:
+  govc host.date.info -host hostname
+  govc host.date.info /dc1/host/cluster1
+  govc host.date.info -max-skew 5s /dc1/host/cluster1
+  govc host.date.info -tz -host hostname`
+}
+
+type dateInfo struct {
+	Host       string        `json:"host"`
+	Time       time.Time     `json:"time"`
+	Skew       time.Duration `json:"skew"`
+	TimeZone   string        `json:"timeZone"`
+	NtpServers []string      `json:"ntpServers"`
+	NtpRunning bool          `json:"ntpRunning"`
+	NtpPolicy  string        `json:"ntpPolicy"`
+}
+
+func (cmd *info) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	if cmd.tz {
+		s, err := hosts[0].ConfigManager().DateTimeSystem(ctx)
+		if err != nil {
+			return err
+		}
+
+		zones, err := s.TimeZones(ctx)
+		if err != nil {
+			return err
+		}
+
+		return cmd.WriteResult(timeZoneResult(zones))
+	}
+
+	var res infoResult
+	var skewed []string
+
+	for _, host := range hosts {
+		info, err := dateTimeInfo(ctx, host)
+		if err != nil {
+			return err
+		}
+
+		res = append(res, info)
+
+		skew := info.Skew
+		if skew < 0 {
+			skew = -skew
+		}
+
+		if cmd.maxSkew > 0 && skew > cmd.maxSkew {
+			skewed = append(skewed, info.Host)
+		}
+	}
+
+	if err = cmd.WriteResult(res); err != nil {
+		return err
+	}
+
+	if len(skewed) != 0 {
+		return fmt.Errorf("clock skew exceeds %s: %s", cmd.maxSkew, strings.Join(skewed, ", "))
+	}
+
+	return nil
+}
+
+func dateTimeInfo(ctx context.Context, host *object.HostSystem) (*dateInfo, error) {
+	s, err := host.ConfigManager().DateTimeSystem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skew, err := s.Skew(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := host.ObjectName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &dateInfo{
+		Host:     name,
+		Time:     time.Now().Add(skew).UTC(),
+		Skew:     skew,
+		TimeZone: config.TimeZone.Name,
+	}
+
+	if config.NtpConfig != nil {
+		info.NtpServers = config.NtpConfig.Server
+	}
+
+	ss, err := host.ConfigManager().ServiceSystem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := ss.Service(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, service := range services {
+		if service.Key == ntpd {
+			info.NtpRunning = service.Running
+			info.NtpPolicy = service.Policy
+		}
+	}
+
+	return info, nil
+}
+
+type infoResult []*dateInfo
+
+func (r infoResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Host\tDate/Time\tSkew\tTime zone\tNTP servers\tNTP service\n")
+
+	for _, info := range r {
+		servers := strings.Join(info.NtpServers, ",")
+		if servers == "" {
+			servers = "-"
+		}
+
+		status := "Stopped"
+		if info.NtpRunning {
+			status = "Running"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s (%s)\n", info.Host, info.Time.Format(time.RFC3339),
+			info.Skew-info.Skew%time.Millisecond, info.TimeZone, servers, status, info.NtpPolicy)
+	}
+
+	return tw.Flush()
+}
+
+type timeZoneResult []types.HostDateTimeSystemTimeZone
+
+func (r timeZoneResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Key\tName\tGMT offset\tDescription\n")
+
+	for _, tz := range r {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", tz.Key, tz.Name, tz.GmtOffset, tz.Description)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,265 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package host
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type health struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+
+	status  string
+	kind    string
+	refresh bool
+	reset   bool
+}
+
+func init() {
+	cli.Register("host.health", &health{})
+}
+
+func (cmd *health) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.status, "status", "", "Only show elements with these statuses (green,yellow,red,unknown)")
+	f.StringVar(&cmd.kind, "type", "", "Only show elements of these types (sensor,memory,cpu,storage) or sensor types (temperature,fan,power,...)")
+	f.BoolVar(&cmd.refresh, "refresh", false, "Refresh health status before reading it")
+	f.BoolVar(&cmd.reset, "reset", false, "Reset system health sensors before reading them")
+}
+
+func (cmd *health) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *health) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *health) Description() string {
+	return `Display hardware health status of HOST or all hosts in CLUSTER.
+
+Includes numeric sensor readings (temperature, fan, power, voltage) and the status
+of memory, cpu and storage elements.  The '-type' flag matches element types and
+sensor types, such that '-type temperature,fan' shows only those sensors.
+
+Examples:
+  govc host.health -host hostname
+  govc host.health -status yellow,red /dc1/host/cluster1
+  govc host.health -type sensor -json -host hostname
+  govc host.health -type temperature,fan -host hostname
+  govc host.health -refresh -host hostname`
+}
+
+type healthElement struct {
+	Host       string   `json:"host"`
+	Type       string   `json:"type"`
+	SensorType string   `json:"sensorType,omitempty"`
+	Name       string   `json:"name"`
+	Status     string   `json:"status"`
+	Summary    string   `json:"summary"`
+	Reading    *float64 `json:"reading,omitempty"`
+	Units      string   `json:"units,omitempty"`
+}
+
+// filter returns a set of the comma separated, lower cased values in s, or nil if s is empty.
+func filter(s string) map[string]bool {
+	if s == "" {
+		return nil
+	}
+
+	m := make(map[string]bool)
+	for _, v := range strings.Split(s, ",") {
+		m[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+
+	return m
+}
+
+func description(d types.BaseElementDescription) (string, string) {
+	if d == nil {
+		return "unknown", ""
+	}
+
+	e := d.GetElementDescription()
+
+	return strings.ToLower(e.Key), e.Summary
+}
+
+// healthElements flattens the health system runtime info.
+func healthElements(name string, rt *types.HealthSystemRuntime) []*healthElement {
+	var res []*healthElement
+
+	if rt.SystemHealthInfo != nil {
+		for _, s := range rt.SystemHealthInfo.NumericSensorInfo {
+			e := &healthElement{
+				Host:       name,
+				Type:       "sensor",
+				SensorType: s.SensorType,
+				Name:       s.Name,
+				Units:      s.BaseUnits,
+			}
+
+			e.Status, e.Summary = description(s.HealthState)
+
+			// The actual reading is CurrentReading * 10^UnitModifier
+			reading := float64(s.CurrentReading) * math.Pow10(int(s.UnitModifier))
+			e.Reading = &reading
+
+			if s.RateUnits != "" {
+				e.Units += "/" + s.RateUnits
+			}
+
+			res = append(res, e)
+		}
+	}
+
+	if rt.HardwareStatusInfo == nil {
+		return res
+	}
+
+	hw := map[string][]types.BaseHostHardwareElementInfo{
+		"memory": rt.HardwareStatusInfo.MemoryStatusInfo,
+		"cpu":    rt.HardwareStatusInfo.CpuStatusInfo,
+	}
+
+	for i := range rt.HardwareStatusInfo.StorageStatusInfo {
+		hw["storage"] = append(hw["storage"], &rt.HardwareStatusInfo.StorageStatusInfo[i])
+	}
+
+	for _, kind := range []string{"memory", "cpu", "storage"} {
+		for _, info := range hw[kind] {
+			i := info.GetHostHardwareElementInfo()
+
+			e := &healthElement{
+				Host: name,
+				Type: kind,
+				Name: i.Name,
+			}
+
+			e.Status, e.Summary = description(i.Status)
+
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
+
+func (cmd *health) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	status := filter(cmd.status)
+	kind := filter(cmd.kind)
+
+	var res healthResult
+
+	for _, host := range hosts {
+		s, err := host.ConfigManager().HealthStatusSystem(ctx)
+		if err != nil {
+			return err
+		}
+
+		if cmd.reset {
+			if err = s.ResetSystemHealthInfo(ctx); err != nil {
+				return err
+			}
+		}
+
+		if cmd.refresh {
+			if err = s.RefreshHealthStatusSystem(ctx); err != nil {
+				return err
+			}
+		}
+
+		rt, err := s.Runtime(ctx)
+		if err != nil {
+			return err
+		}
+
+		name, err := host.ObjectName(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range healthElements(name, rt) {
+			if status != nil && !status[e.Status] {
+				continue
+			}
+
+			if kind != nil && !kind[e.Type] && !kind[strings.ToLower(e.SensorType)] {
+				continue
+			}
+
+			res = append(res, e)
+		}
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type healthResult []*healthElement
+
+func (r healthResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Host\tType\tSensor\tName\tStatus\tReading\n")
+
+	for _, e := range r {
+		reading := "-"
+		if e.Reading != nil {
+			reading = strconv.FormatFloat(*e.Reading, 'f', -1, 64)
+			if e.Units != "" {
+				reading += " " + e.Units
+			}
+		}
+
+		sensor := e.SensorType
+		if sensor == "" {
+			sensor = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Host, e.Type, sensor, e.Name, e.Status, reading)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package module
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type ls struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+
+	loaded bool
+}
+
+func init() {
+	cli.Register("host.module.ls", &ls{})
+}
+
+func (cmd *ls) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.loaded, "loaded", false, "List loaded modules only")
+}
+
+func (cmd *ls) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *ls) Usage() string {
+	return "[NAME]..."
+}
+
+func (cmd *ls) Description() string {
+	return `List kernel modules of the host, or only the modules with the given NAMEs.
+
+Examples:
+  govc host.module.ls -host hostname
+  govc host.module.ls -host hostname -loaded
+  govc host.module.ls -host hostname -json ixgbe`
+}
+
+func (cmd *ls) Run(ctx context.Context, f *flag.FlagSet) error {
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	s, err := host.ConfigManager().KernelModuleSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	modules, err := s.QueryModules(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	for _, name := range f.Args() {
+		names[name] = false
+	}
+
+	var res lsResult
+
+	for _, m := range modules {
+		if cmd.loaded && !m.Loaded {
+			continue
+		}
+
+		if len(names) != 0 {
+			if _, ok := names[m.Name]; !ok {
+				continue
+			}
+			names[m.Name] = true
+		}
+
+		res = append(res, m)
+	}
+
+	for name, found := range names {
+		if !found {
+			return fmt.Errorf("module %s not found", name)
+		}
+	}
+
+	sort.Sort(res)
+
+	return cmd.WriteResult(res)
+}
+
+type lsResult []types.KernelModuleInfo
+
+func (r lsResult) Len() int           { return len(r) }
+func (r lsResult) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r lsResult) Less(i, j int) bool { return r[i].Name < r[j].Name }
+
+func (r lsResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Name\tLoaded\tEnabled\tUse count\tOptions\n")
+
+	for _, m := range r {
+		fmt.Fprintf(tw, "%s\t%t\t%t\t%d\t%s\n", m.Name, m.Loaded, m.Enabled, m.UseCount, m.OptionString)
+	}
+
+	return tw.Flush()
+}
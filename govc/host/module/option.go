@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package module
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type option struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+
+	set *string
+}
+
+func init() {
+	cli.Register("host.module.option", &option{})
+}
+
+func (cmd *option) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.Var(flags.NewOptionalString(&cmd.set), "set", "Set the module option string, an empty string clears the options")
+}
+
+func (cmd *option) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *option) Usage() string {
+	return "NAME [HOST|CLUSTER]..."
+}
+
+func (cmd *option) Description() string {
+	return `Display or set the option string of kernel module NAME on HOST or all hosts in CLUSTER.
+
+The configured option string takes effect the next time the module is loaded,
+typically after a host reboot.
+
+Examples:
+  govc host.module.option -host hostname ixgbe
+  govc host.module.option -set "RSS=4,4" ixgbe /dc1/host/cluster1`
+}
+
+type optionInfo struct {
+	Host    string `json:"host"`
+	Module  string `json:"module"`
+	Options string `json:"options"`
+}
+
+func (cmd *option) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	name := f.Arg(0)
+
+	hosts, err := cmd.HostSystemsOrDefault(f.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	var res optionResult
+
+	for _, host := range hosts {
+		s, err := host.ConfigManager().KernelModuleSystem(ctx)
+		if err != nil {
+			return err
+		}
+
+		if cmd.set != nil {
+			if err = s.UpdateModuleOptionString(ctx, name, *cmd.set); err != nil {
+				return err
+			}
+			continue
+		}
+
+		options, err := s.QueryConfiguredModuleOptionString(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		hostname, err := host.ObjectName(ctx)
+		if err != nil {
+			return err
+		}
+
+		res = append(res, &optionInfo{
+			Host:    hostname,
+			Module:  name,
+			Options: options,
+		})
+	}
+
+	if cmd.set != nil {
+		return nil
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type optionResult []*optionInfo
+
+func (r optionResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Host\tModule\tOptions\n")
+
+	for _, info := range r {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", info.Host, info.Module, info.Options)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package power
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type policy struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+
+	set string
+}
+
+func init() {
+	cli.Register("host.power.policy", &policy{})
+}
+
+func (cmd *policy) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.set, "set", "", "Set the power policy by key or short name")
+}
+
+func (cmd *policy) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *policy) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *policy) Description() string {
+	return `Display or set the power management policy of HOST or all hosts in CLUSTER.
+
+The available policies are listed with the current policy marked by '*'.
+Policy short names include "static" (high performance), "dynamic" (balanced),
+"low" (low power) and "custom".
+
+Examples:
+  govc host.power.policy -host hostname
+  govc host.power.policy -set static /dc1/host/cluster1
+  govc host.power.policy -set dynamic -host hostname`
+}
+
+type policyInfo struct {
+	Host      string                  `json:"host"`
+	Current   types.HostPowerPolicy   `json:"current"`
+	Available []types.HostPowerPolicy `json:"available"`
+}
+
+// match returns the policy matching the given key or short name.
+func match(policies []types.HostPowerPolicy, s string) (*types.HostPowerPolicy, error) {
+	key, err := strconv.Atoi(s)
+
+	for i := range policies {
+		p := &policies[i]
+
+		if err == nil && p.Key == int32(key) {
+			return p, nil
+		}
+
+		if strings.EqualFold(p.ShortName, s) {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("power policy %q not found", s)
+}
+
+func (cmd *policy) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	var res policyResult
+
+	for _, host := range hosts {
+		s, err := host.ConfigManager().PowerSystem(ctx)
+		if err != nil {
+			return err
+		}
+
+		available, current, err := s.Policies(ctx)
+		if err != nil {
+			return err
+		}
+
+		if cmd.set != "" {
+			p, err := match(available, cmd.set)
+			if err != nil {
+				return err
+			}
+
+			if p.Key != current.Key {
+				if err = s.ConfigurePowerPolicy(ctx, p.Key); err != nil {
+					return err
+				}
+				current = p
+			}
+		}
+
+		name, err := host.ObjectName(ctx)
+		if err != nil {
+			return err
+		}
+
+		res = append(res, &policyInfo{
+			Host:      name,
+			Current:   *current,
+			Available: available,
+		})
+	}
+
+	if cmd.set != "" {
+		return nil
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type policyResult []*policyInfo
+
+func (r policyResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, info := range r {
+		fmt.Fprintf(tw, "%s:\n", info.Host)
+
+		for _, p := range info.Available {
+			mark := " "
+			if p.Key == info.Current.Key {
+				mark = "*"
+			}
+
+			fmt.Fprintf(tw, "  %s %d\t%s\t%s\t%s\n", mark, p.Key, p.ShortName, p.Name, p.Description)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,236 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type apply struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+
+	profile string
+	plan    bool
+}
+
+func init() {
+	cli.Register("host.profile.apply", &apply{})
+}
+
+func (cmd *apply) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.profile, "profile", "", "Host profile name")
+	f.BoolVar(&cmd.plan, "plan", false, "Print the configuration tasks without applying them")
+}
+
+func (cmd *apply) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *apply) Usage() string {
+	return "HOST|CLUSTER..."
+}
+
+func (cmd *apply) Description() string {
+	return `Apply host profile to HOST or all hosts in CLUSTER.
+
+The configuration tasks needed to bring each host in line with the profile are printed,
+then applied unless -plan is specified. Hosts must be in maintenance mode
+if any of the tasks require it.
+
+Examples:
+  govc host.profile.apply -profile golden -plan /dc1/host/cluster1
+  govc host.profile.apply -profile golden /dc1/host/cluster1/host1`
+}
+
+func (cmd *apply) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	m, err := object.GetHostProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	p, err := profileByName(ctx, c, cmd.profile)
+	if err != nil {
+		return err
+	}
+
+	hosts, err := cmd.HostSystems(f.Args())
+	if err != nil {
+		return err
+	}
+
+	var res applyResult
+
+	for _, host := range hosts {
+		r, err := cmd.apply(ctx, m, p, host)
+		if r != nil {
+			res = append(res, r)
+		}
+		if err != nil {
+			if len(res) != 0 {
+				// Report the changes made before the error
+				_ = cmd.WriteResult(res)
+			}
+			return err
+		}
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type applyInfo struct {
+	Host           string   `json:"host"`
+	Tasks          []string `json:"tasks"`
+	Applied        bool     `json:"applied"`
+	RebootRequired bool     `json:"rebootRequired"`
+}
+
+func (cmd *apply) apply(ctx context.Context, m *object.HostProfileManager, p *object.HostProfile, host *object.HostSystem) (*applyInfo, error) {
+	name, err := host.ObjectName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.ExecuteHostProfile(ctx, host, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch types.ProfileExecuteResultStatus(res.Status) {
+	case types.ProfileExecuteResultStatusSuccess:
+	case types.ProfileExecuteResultStatusNeedInput:
+		var paths []string
+		for _, input := range res.RequireInput {
+			paths = append(paths, input.InputPath.ProfilePath)
+		}
+		return nil, fmt.Errorf("%s: profile requires input: %s", name, strings.Join(paths, ", "))
+	default:
+		var msgs []string
+		for _, e := range res.Error {
+			msgs = append(msgs, e.Message.Message)
+		}
+		return nil, fmt.Errorf("%s: %s", name, strings.Join(msgs, "; "))
+	}
+
+	info := &applyInfo{Host: name}
+
+	if res.ConfigSpec == nil {
+		return info, nil
+	}
+
+	list, err := m.GenerateConfigTaskList(ctx, *res.ConfigSpec, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list.TaskDescription) == 0 || list.ConfigSpec == nil {
+		return info, nil
+	}
+
+	for _, task := range list.TaskDescription {
+		info.Tasks = append(info.Tasks, task.Message)
+	}
+
+	if cmd.plan {
+		return info, nil
+	}
+
+	for _, r := range list.TaskListRequirement {
+		switch types.HostProfileManagerTaskListRequirement(r) {
+		case types.HostProfileManagerTaskListRequirementMaintenanceModeRequired:
+			var h mo.HostSystem
+			err = host.Properties(ctx, host.Reference(), []string{"runtime.inMaintenanceMode"}, &h)
+			if err != nil {
+				return nil, err
+			}
+
+			if !h.Runtime.InMaintenanceMode {
+				return info, fmt.Errorf("%s: host must be in maintenance mode to apply profile", name)
+			}
+		case types.HostProfileManagerTaskListRequirementRebootRequired:
+			info.RebootRequired = true
+		}
+	}
+
+	task, err := m.ApplyHostConfig(ctx, host, *list.ConfigSpec, nil)
+	if err != nil {
+		return info, err
+	}
+
+	logger := cmd.ProgressLogger(fmt.Sprintf("%s: applying profile %s... ", name, cmd.profile))
+	_, err = task.WaitForResult(ctx, logger)
+	logger.Wait()
+	if err != nil {
+		return info, err
+	}
+
+	info.Applied = true
+
+	return info, nil
+}
+
+type applyResult []*applyInfo
+
+func (r applyResult) Write(w io.Writer) error {
+	for _, info := range r {
+		if len(info.Tasks) == 0 {
+			fmt.Fprintf(w, "%s: no changes\n", info.Host)
+			continue
+		}
+
+		for _, task := range info.Tasks {
+			fmt.Fprintf(w, "%s: %s\n", info.Host, task)
+		}
+
+		if info.RebootRequired {
+			fmt.Fprintf(w, "%s: reboot required\n", info.Host)
+		}
+	}
+
+	return nil
+}
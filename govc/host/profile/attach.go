@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type attach struct {
+	*flags.DatacenterFlag
+
+	attach bool
+}
+
+func init() {
+	cli.Register("host.profile.attach", &attach{attach: true})
+	cli.Register("host.profile.detach", &attach{attach: false})
+}
+
+func (cmd *attach) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+}
+
+func (cmd *attach) Process(ctx context.Context) error {
+	if err := cmd.DatacenterFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *attach) Usage() string {
+	if cmd.attach {
+		return "PROFILE HOST|CLUSTER..."
+	}
+	return "PROFILE [HOST|CLUSTER]..."
+}
+
+func (cmd *attach) Description() string {
+	return `Attach or detach host profile PROFILE to the given hosts and clusters.
+
+When detaching without any HOST or CLUSTER arguments, PROFILE is detached from all entities.
+
+Examples:
+  govc host.profile.attach golden /dc1/host/cluster1 /dc1/host/host2
+  govc host.profile.detach golden /dc1/host/host2
+  govc host.profile.detach golden`
+}
+
+func (cmd *attach) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 || (cmd.attach && f.NArg() == 1) {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	p, err := profileByName(ctx, c, f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	refs, err := entities(ctx, cmd.DatacenterFlag, f.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	if cmd.attach {
+		return p.AssociateProfile(ctx, refs)
+	}
+
+	return p.DissociateProfile(ctx, refs)
+}
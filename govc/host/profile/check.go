@@ -0,0 +1,192 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type check struct {
+	*flags.DatacenterFlag
+	*flags.OutputFlag
+
+	profile string
+}
+
+func init() {
+	cli.Register("host.profile.check", &check{})
+}
+
+func (cmd *check) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.profile, "profile", "", "Host profile name")
+}
+
+func (cmd *check) Process(ctx context.Context) error {
+	if err := cmd.DatacenterFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *check) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *check) Description() string {
+	return `Check compliance of hosts and clusters against their attached host profiles.
+
+If -profile is specified, compliance is checked against that profile only.
+If no HOST or CLUSTER is given, all entities attached to the profile are checked.
+
+Examples:
+  govc host.profile.check /dc1/host/cluster1
+  govc host.profile.check -profile golden
+  govc host.profile.check -json -profile golden | jq '.[] | select(.status != "compliant")'`
+}
+
+type complianceInfo struct {
+	Entity    string     `json:"entity"`
+	Profile   string     `json:"profile"`
+	Status    string     `json:"status"`
+	CheckTime *time.Time `json:"checkTime"`
+	Failures  []string   `json:"failures"`
+}
+
+func (cmd *check) Run(ctx context.Context, f *flag.FlagSet) error {
+	if cmd.profile == "" && f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	m, err := object.GetProfileComplianceManager(c)
+	if err != nil {
+		return err
+	}
+
+	var profiles []types.ManagedObjectReference
+
+	if cmd.profile != "" {
+		p, err := profileByName(ctx, c, cmd.profile)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, p.Reference())
+	}
+
+	refs, err := entities(ctx, cmd.DatacenterFlag, f.Args())
+	if err != nil {
+		return err
+	}
+
+	task, err := m.CheckCompliance(ctx, profiles, refs)
+	if err != nil {
+		return err
+	}
+
+	logger := cmd.ProgressLogger("Checking compliance... ")
+	info, err := task.WaitForResult(ctx, logger)
+	logger.Wait()
+	if err != nil {
+		return err
+	}
+
+	var results []types.ComplianceResult
+	if r, ok := info.Result.(types.ArrayOfComplianceResult); ok {
+		results = r.ComplianceResult
+	}
+
+	var all []types.ManagedObjectReference
+	for _, r := range results {
+		if r.Entity != nil {
+			all = append(all, *r.Entity)
+		}
+		if r.Profile != nil {
+			all = append(all, *r.Profile)
+		}
+	}
+
+	byRef, err := names(ctx, c, all)
+	if err != nil {
+		return err
+	}
+
+	var res checkResult
+
+	for _, r := range results {
+		ci := &complianceInfo{
+			Status:    r.ComplianceStatus,
+			CheckTime: r.CheckTime,
+		}
+
+		if r.Entity != nil {
+			ci.Entity = byRef[*r.Entity]
+		}
+
+		if r.Profile != nil {
+			ci.Profile = byRef[*r.Profile]
+		}
+
+		for _, failure := range r.Failure {
+			ci.Failures = append(ci.Failures, failure.Message.Message)
+		}
+
+		res = append(res, ci)
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type checkResult []*complianceInfo
+
+func (r checkResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Entity\tProfile\tStatus\n")
+
+	for _, ci := range r {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", ci.Entity, ci.Profile, ci.Status)
+
+		for _, failure := range ci.Failures {
+			fmt.Fprintf(tw, "  %s\n", failure)
+		}
+	}
+
+	return tw.Flush()
+}
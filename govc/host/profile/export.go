@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type export struct {
+	*flags.ClientFlag
+}
+
+func init() {
+	cli.Register("host.profile.export", &export{})
+}
+
+func (cmd *export) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+}
+
+func (cmd *export) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *export) Usage() string {
+	return "PROFILE [FILE]"
+}
+
+func (cmd *export) Description() string {
+	return `Export host profile PROFILE in its serialized form to FILE or stdout.
+
+Examples:
+  govc host.profile.export golden golden.vpf
+  govc host.profile.import golden-copy golden.vpf`
+}
+
+func (cmd *export) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 || f.NArg() > 2 {
+		return flag.ErrHelp
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	p, err := profileByName(ctx, c, f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	data, err := p.ExportProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	if f.NArg() == 1 || f.Arg(1) == "-" {
+		_, err = os.Stdout.WriteString(data)
+		return err
+	}
+
+	return ioutil.WriteFile(f.Arg(1), []byte(data), 0644)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type importx struct {
+	*flags.ClientFlag
+
+	annotation string
+}
+
+func init() {
+	cli.Register("host.profile.import", &importx{})
+}
+
+func (cmd *importx) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.annotation, "d", "", "Profile description")
+}
+
+func (cmd *importx) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *importx) Usage() string {
+	return "NAME FILE"
+}
+
+func (cmd *importx) Description() string {
+	return `Create host profile NAME from FILE, as created by host.profile.export.
+
+If FILE is "-", the profile is read from stdin.
+
+Examples:
+  govc host.profile.import -d "restored" golden golden.vpf`
+}
+
+func (cmd *importx) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 2 {
+		return flag.ErrHelp
+	}
+
+	var data []byte
+	var err error
+
+	if name := f.Arg(1); name == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	m, err := object.GetHostProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Import(ctx, f.Arg(0), cmd.annotation, string(data))
+	return err
+}
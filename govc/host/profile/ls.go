@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type ls struct {
+	*flags.ClientFlag
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("host.profile.ls", &ls{})
+}
+
+func (cmd *ls) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.ClientFlag, ctx = flags.NewClientFlag(ctx)
+	cmd.ClientFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *ls) Process(ctx context.Context) error {
+	if err := cmd.ClientFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *ls) Description() string {
+	return `List host profiles.
+
+Examples:
+  govc host.profile.ls
+  govc host.profile.ls -json`
+}
+
+type profileInfo struct {
+	Name             string   `json:"name"`
+	Annotation       string   `json:"annotation"`
+	ReferenceHost    string   `json:"referenceHost"`
+	Entity           []string `json:"entity"`
+	ComplianceStatus string   `json:"complianceStatus"`
+}
+
+func (cmd *ls) Run(ctx context.Context, f *flag.FlagSet) error {
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	hps, err := profiles(ctx, c, "config", "referenceHost", "entity", "complianceStatus")
+	if err != nil {
+		return err
+	}
+
+	var refs []types.ManagedObjectReference
+	for _, p := range hps {
+		if p.ReferenceHost != nil {
+			refs = append(refs, *p.ReferenceHost)
+		}
+		refs = append(refs, p.Entity...)
+	}
+
+	m, err := names(ctx, c, refs)
+	if err != nil {
+		return err
+	}
+
+	var res lsResult
+
+	for _, p := range hps {
+		info := &profileInfo{
+			Name:             p.Name,
+			ComplianceStatus: p.ComplianceStatus,
+		}
+
+		if p.Config != nil {
+			info.Annotation = p.Config.GetProfileConfigInfo().Annotation
+		}
+
+		if p.ReferenceHost != nil {
+			info.ReferenceHost = m[*p.ReferenceHost]
+		}
+
+		for _, e := range p.Entity {
+			info.Entity = append(info.Entity, m[e])
+		}
+
+		res = append(res, info)
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type lsResult []*profileInfo
+
+func (r lsResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Name\tReference host\tEntities\tCompliance\n")
+
+	for _, p := range r {
+		host := p.ReferenceHost
+		if host == "" {
+			host = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", p.Name, host, len(p.Entity), p.ComplianceStatus)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/property"
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// profiles returns the properties of all host profiles.
+func profiles(ctx context.Context, c *vim25.Client, props ...string) ([]mo.HostProfile, error) {
+	m, err := object.GetHostProfileManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := m.Profiles(ctx)
+	if err != nil || len(refs) == 0 {
+		return nil, err
+	}
+
+	var objs []types.ManagedObjectReference
+	for _, p := range refs {
+		objs = append(objs, p.Reference())
+	}
+
+	var hps []mo.HostProfile
+
+	pc := property.DefaultCollector(c)
+	err = pc.Retrieve(ctx, objs, append([]string{"name"}, props...), &hps)
+	if err != nil {
+		return nil, err
+	}
+
+	return hps, nil
+}
+
+// profileByName returns the host profile with the given name.
+func profileByName(ctx context.Context, c *vim25.Client, name string) (*object.HostProfile, error) {
+	if name == "" {
+		return nil, fmt.Errorf("profile name is required")
+	}
+
+	hps, err := profiles(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range hps {
+		if p.Name == name {
+			return object.NewHostProfile(c, p.Self), nil
+		}
+	}
+
+	return nil, fmt.Errorf("host profile %q not found", name)
+}
+
+// entities returns the hosts and clusters matching the given inventory paths.
+func entities(ctx context.Context, flag *flags.DatacenterFlag, args []string) ([]types.ManagedObjectReference, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	refs, err := flag.ManagedObjects(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("%s not found", strings.Join(args, ", "))
+	}
+
+	for _, ref := range refs {
+		switch ref.Type {
+		case "HostSystem", "ClusterComputeResource":
+		default:
+			return nil, fmt.Errorf("%s is not a host or cluster", ref)
+		}
+	}
+
+	return refs, nil
+}
+
+// names maps the given references to their names.
+func names(ctx context.Context, c *vim25.Client, refs []types.ManagedObjectReference) (map[types.ManagedObjectReference]string, error) {
+	m := make(map[types.ManagedObjectReference]string)
+
+	var objs []mo.ManagedEntity
+	var unique []types.ManagedObjectReference
+
+	for _, ref := range refs {
+		if _, ok := m[ref]; !ok {
+			m[ref] = ref.String()
+			unique = append(unique, ref)
+		}
+	}
+
+	if len(unique) == 0 {
+		return m, nil
+	}
+
+	pc := property.DefaultCollector(c)
+	err := pc.Retrieve(ctx, unique, []string{"name"}, &objs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range objs {
+		m[o.Self] = o.Name
+	}
+
+	return m, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package host
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type reboot struct {
+	*flags.HostSystemFlag
+
+	force   bool
+	wait    bool
+	timeout time.Duration
+}
+
+func init() {
+	cli.Register("host.reboot", &reboot{})
+}
+
+func (cmd *reboot) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.force, "f", false, "Reboot even if the host is not in maintenance mode")
+	f.BoolVar(&cmd.wait, "wait", true, "Wait for the host to reconnect (vCenter only)")
+	f.DurationVar(&cmd.timeout, "timeout", 0, "Maximum time to wait for the host to reconnect")
+}
+
+func (cmd *reboot) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *reboot) Usage() string {
+	return "HOST..."
+}
+
+func (cmd *reboot) Description() string {
+	return `Reboot HOST or all hosts in CLUSTER.
+
+Hosts are rebooted one at a time. When connected to vCenter, each reboot waits for
+the host to disconnect and reconnect before moving on to the next host, unless -wait=false.
+
+Examples:
+  govc host.reboot -host hostname
+  govc host.reboot -timeout 20m /dc1/host/cluster1/host1 /dc1/host/cluster1/host2`
+}
+
+func (cmd *reboot) Reboot(ctx context.Context, host *object.HostSystem) error {
+	task, err := host.Reboot(ctx, cmd.force)
+	if err != nil {
+		return err
+	}
+
+	logger := cmd.ProgressLogger(fmt.Sprintf("%s rebooting... ", host.InventoryPath))
+	_, err = task.WaitForResult(ctx, logger)
+	logger.Wait()
+	if err != nil {
+		return err
+	}
+
+	if !cmd.wait || !host.Client().IsVC() {
+		return nil
+	}
+
+	if cmd.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmd.timeout)
+		defer cancel()
+	}
+
+	_, _ = cmd.Log(fmt.Sprintf("%s waiting for host to reconnect...\n", host.InventoryPath))
+
+	// Wait for the host to go down before waiting for it to come back up
+	connected := types.HostSystemConnectionStateConnected
+
+	if err = host.WaitForConnectionState(ctx, connected, true); err != nil {
+		return err
+	}
+
+	return host.WaitForConnectionState(ctx, connected, false)
+}
+
+func (cmd *reboot) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystems(f.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		err = cmd.Reboot(ctx, host)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
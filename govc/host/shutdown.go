@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package host
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type shutdown struct {
+	*flags.HostSystemFlag
+
+	force    bool
+	standby  bool
+	evacuate bool
+}
+
+func init() {
+	cli.Register("host.shutdown", &shutdown{})
+}
+
+func (cmd *shutdown) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.force, "f", false, "Shutdown even if the host is not in maintenance mode")
+	f.BoolVar(&cmd.standby, "standby", false, "Put the host in standby mode instead (vCenter only)")
+	f.BoolVar(&cmd.evacuate, "evacuate", false, "Evacuate powered off VMs before entering standby mode")
+}
+
+func (cmd *shutdown) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *shutdown) Usage() string {
+	return "HOST..."
+}
+
+func (cmd *shutdown) Description() string {
+	return `Shutdown HOST or all hosts in CLUSTER.
+
+With the -standby flag, hosts are put in standby mode and can be powered up again by vCenter.
+
+Examples:
+  govc host.shutdown -host hostname
+  govc host.shutdown -f /dc1/host/cluster1/host1
+  govc host.shutdown -standby -evacuate /dc1/host/cluster1/host1`
+}
+
+func (cmd *shutdown) Shutdown(ctx context.Context, host *object.HostSystem) error {
+	var task *object.Task
+	var err error
+
+	if cmd.standby {
+		task, err = host.PowerDownToStandBy(ctx, 0, cmd.evacuate)
+	} else {
+		task, err = host.Shutdown(ctx, cmd.force)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	logger := cmd.ProgressLogger(fmt.Sprintf("%s shutting down... ", host.InventoryPath))
+	defer logger.Wait()
+
+	_, err = task.WaitForResult(ctx, logger)
+	return err
+}
+
+func (cmd *shutdown) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystems(f.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		err = cmd.Shutdown(ctx, host)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
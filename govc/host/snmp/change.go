@@ -0,0 +1,225 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snmp
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// list is a repeatable, comma separated flag.
+// A nil list was not specified, an empty value clears the list.
+type list []string
+
+func (l *list) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *list) Set(value string) error {
+	if *l == nil {
+		*l = list{}
+	}
+
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+
+	return nil
+}
+
+type change struct {
+	*flags.HostSystemFlag
+
+	enabled     *bool
+	port        int32
+	communities list
+	targets     list
+	options     list
+	test        bool
+}
+
+func init() {
+	cli.Register("host.snmp.change", &change{})
+}
+
+func (cmd *change) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.Var(flags.NewOptionalBool(&cmd.enabled), "enabled", "Enable or disable the SNMP agent")
+	f.Var(flags.NewInt32(&cmd.port), "port", "Agent UDP port")
+	f.Var(&cmd.communities, "community", "Read only communities (replaces existing)")
+	f.Var(&cmd.targets, "target", "Trap targets as HOST[:PORT]/COMMUNITY (replaces existing)")
+	f.Var(&cmd.options, "option", "Agent options as KEY=VALUE (merged with existing)")
+	f.BoolVar(&cmd.test, "test", false, "Send a test notification to the trap targets")
+}
+
+func (cmd *change) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *change) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *change) Description() string {
+	return `Change SNMP agent configuration of HOST or all hosts in CLUSTER.
+
+Communities and trap targets can be specified multiple times or comma separated,
+an empty value clears the list. The trap target port defaults to 162.
+
+Examples:
+  govc host.snmp.change -enabled -community public -target 10.0.0.5/public /dc1/host/cluster1
+  govc host.snmp.change -target 10.0.0.5:1162/public,10.0.0.6/public -host hostname
+  govc host.snmp.change -option syscontact=ops@example.com -test -host hostname
+  govc host.snmp.change -enabled=false -community "" -target "" -host hostname`
+}
+
+// parseTarget parses HOST[:PORT]/COMMUNITY
+func parseTarget(s string) (*types.HostSnmpDestination, error) {
+	i := strings.LastIndex(s, "/")
+	if i <= 0 || i == len(s)-1 {
+		return nil, fmt.Errorf("invalid target %q, expected HOST[:PORT]/COMMUNITY", s)
+	}
+
+	d := &types.HostSnmpDestination{
+		HostName:  s[:i],
+		Port:      162,
+		Community: s[i+1:],
+	}
+
+	if host, port, err := net.SplitHostPort(d.HostName); err == nil {
+		n, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in target %q", s)
+		}
+		d.HostName = host
+		d.Port = int32(n)
+	}
+
+	return d, nil
+}
+
+func (cmd *change) spec(spec types.HostSnmpConfigSpec) (*types.HostSnmpConfigSpec, error) {
+	if cmd.enabled != nil {
+		spec.Enabled = cmd.enabled
+	}
+
+	if cmd.port != 0 {
+		spec.Port = cmd.port
+	}
+
+	if cmd.communities != nil {
+		spec.ReadOnlyCommunities = cmd.communities
+	}
+
+	if cmd.targets != nil {
+		spec.TrapTargets = nil
+
+		for _, t := range cmd.targets {
+			d, err := parseTarget(t)
+			if err != nil {
+				return nil, err
+			}
+			spec.TrapTargets = append(spec.TrapTargets, *d)
+		}
+	}
+
+	for _, o := range cmd.options {
+		kv := strings.SplitN(o, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid option %q, expected KEY=VALUE", o)
+		}
+
+		option := types.KeyValue{Key: kv[0], Value: kv[1]}
+		found := false
+
+		for i := range spec.Option {
+			if spec.Option[i].Key == option.Key {
+				spec.Option[i] = option
+				found = true
+			}
+		}
+
+		if !found {
+			spec.Option = append(spec.Option, option)
+		}
+	}
+
+	return &spec, nil
+}
+
+func (cmd *change) Run(ctx context.Context, f *flag.FlagSet) error {
+	// Validate flags before making any changes
+	if _, err := cmd.spec(types.HostSnmpConfigSpec{}); err != nil {
+		return err
+	}
+
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if err = cmd.change(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cmd *change) change(ctx context.Context, host *object.HostSystem) error {
+	s, err := host.ConfigManager().SnmpSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.Configuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec, err := cmd.spec(*current)
+	if err != nil {
+		return err
+	}
+
+	if err = s.ReconfigureSnmpAgent(ctx, *spec); err != nil {
+		return err
+	}
+
+	if cmd.test {
+		return s.SendTestNotification(ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snmp
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type info struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("host.snmp.info", &info{})
+}
+
+func (cmd *info) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *info) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *info) Usage() string {
+	return "[HOST|CLUSTER]..."
+}
+
+func (cmd *info) Description() string {
+	return `Display SNMP agent configuration of HOST or all hosts in CLUSTER.
+
+Examples:
+  govc host.snmp.info -host hostname
+  govc host.snmp.info -json /dc1/host/cluster1`
+}
+
+// formatTarget formats d as HOST:PORT/COMMUNITY, the format accepted by host.snmp.change -target.
+func formatTarget(d types.HostSnmpDestination) string {
+	return net.JoinHostPort(d.HostName, strconv.Itoa(int(d.Port))) + "/" + d.Community
+}
+
+type snmpInfo struct {
+	Host string `json:"host"`
+	*types.HostSnmpConfigSpec
+}
+
+func (cmd *info) Run(ctx context.Context, f *flag.FlagSet) error {
+	hosts, err := cmd.HostSystemsOrDefault(f.Args())
+	if err != nil {
+		return err
+	}
+
+	var res infoResult
+
+	for _, host := range hosts {
+		s, err := host.ConfigManager().SnmpSystem(ctx)
+		if err != nil {
+			return err
+		}
+
+		spec, err := s.Configuration(ctx)
+		if err != nil {
+			return err
+		}
+
+		name, err := host.ObjectName(ctx)
+		if err != nil {
+			return err
+		}
+
+		res = append(res, &snmpInfo{Host: name, HostSnmpConfigSpec: spec})
+	}
+
+	return cmd.WriteResult(res)
+}
+
+type infoResult []*snmpInfo
+
+func (r infoResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, info := range r {
+		enabled := info.Enabled != nil && *info.Enabled
+
+		var targets []string
+		for _, d := range info.TrapTargets {
+			targets = append(targets, formatTarget(d))
+		}
+
+		var options []string
+		for _, o := range info.Option {
+			options = append(options, o.Key+"="+o.Value)
+		}
+
+		fmt.Fprintf(tw, "Host:\t%s\n", info.Host)
+		fmt.Fprintf(tw, "  Enabled:\t%t\n", enabled)
+		fmt.Fprintf(tw, "  Port:\t%d\n", info.Port)
+		fmt.Fprintf(tw, "  Communities:\t%s\n", strings.Join(info.ReadOnlyCommunities, ","))
+		fmt.Fprintf(tw, "  Trap targets:\t%s\n", strings.Join(targets, ","))
+		fmt.Fprintf(tw, "  Options:\t%s\n", strings.Join(options, ","))
+	}
+
+	return tw.Flush()
+}
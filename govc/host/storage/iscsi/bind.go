@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iscsi
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type bind struct {
+	*flags.HostSystemFlag
+
+	bind  bool
+	hba   string
+	force bool
+}
+
+func init() {
+	cli.Register("host.storage.iscsi.bind", &bind{bind: true})
+	cli.Register("host.storage.iscsi.unbind", &bind{bind: false})
+}
+
+func (cmd *bind) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.hba, "hba", "", "iSCSI adapter device name, defaults to the software adapter")
+	if !cmd.bind {
+		f.BoolVar(&cmd.force, "force", false, "Unbind even if the vnic has active sessions")
+	}
+}
+
+func (cmd *bind) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *bind) Usage() string {
+	return "VNIC..."
+}
+
+func (cmd *bind) Description() string {
+	return `Bind or unbind VMkernel nics to an iSCSI adapter for port binding.
+
+Examples:
+  govc host.storage.iscsi.bind -host hostname vmk1 vmk2
+  govc host.storage.iscsi.unbind -host hostname -hba vmhba33 vmk2`
+}
+
+func (cmd *bind) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	_, hba, err := iscsiHba(ctx, host, cmd.hba)
+	if err != nil {
+		return err
+	}
+
+	m, err := host.ConfigManager().IscsiManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, vnic := range f.Args() {
+		if cmd.bind {
+			err = m.BindVnic(ctx, hba.Device, vnic)
+		} else {
+			err = m.UnbindVnic(ctx, hba.Device, vnic, cmd.force)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iscsi
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+var chapTypes = map[string]types.HostInternetScsiHbaChapAuthenticationType{
+	"prohibited":  types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited,
+	"discouraged": types.HostInternetScsiHbaChapAuthenticationTypeChapDiscouraged,
+	"preferred":   types.HostInternetScsiHbaChapAuthenticationTypeChapPreferred,
+	"required":    types.HostInternetScsiHbaChapAuthenticationTypeChapRequired,
+}
+
+type chap struct {
+	*flags.HostSystemFlag
+
+	hba     string
+	target  string
+	iqn     string
+	level   string
+	name    string
+	secret  string
+	mname   string
+	msecret string
+	inherit bool
+	disable bool
+}
+
+func init() {
+	cli.Register("host.storage.iscsi.chap", &chap{})
+}
+
+func (cmd *chap) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.hba, "hba", "", "iSCSI adapter device name, defaults to the software adapter")
+	f.StringVar(&cmd.target, "target", "", "Apply to target ADDRESS[:PORT] instead of the adapter")
+	f.StringVar(&cmd.iqn, "iqn", "", "Target IQN, if -target is a static target")
+	f.StringVar(&cmd.level, "level", "required", "CHAP level (prohibited|discouraged|preferred|required)")
+	f.StringVar(&cmd.name, "name", "", "CHAP name")
+	f.StringVar(&cmd.secret, "secret", "", "CHAP secret")
+	f.StringVar(&cmd.mname, "mutual-name", "", "Mutual CHAP name")
+	f.StringVar(&cmd.msecret, "mutual-secret", "", "Mutual CHAP secret")
+	f.BoolVar(&cmd.inherit, "inherit", false, "Inherit CHAP settings from the adapter, requires -target")
+	f.BoolVar(&cmd.disable, "disable", false, "Disable CHAP")
+}
+
+func (cmd *chap) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *chap) Description() string {
+	return `Configure iSCSI CHAP authentication.
+
+CHAP settings apply to the adapter unless -target is specified,
+in which case the settings apply to the given send or static target.
+Mutual CHAP is enabled when -mutual-name is specified.
+
+Examples:
+  govc host.storage.iscsi.chap -host hostname -name user -secret password
+  govc host.storage.iscsi.chap -host hostname -name user -secret password -mutual-name target -mutual-secret password2
+  govc host.storage.iscsi.chap -host hostname -target 10.0.0.5 -inherit
+  govc host.storage.iscsi.chap -host hostname -disable`
+}
+
+func (cmd *chap) properties() (*types.HostInternetScsiHbaAuthenticationProperties, error) {
+	prohibited := string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited)
+
+	props := &types.HostInternetScsiHbaAuthenticationProperties{
+		ChapAuthenticationType:       prohibited,
+		MutualChapAuthenticationType: prohibited,
+	}
+
+	if cmd.target != "" {
+		props.ChapInherited = types.NewBool(cmd.inherit)
+		props.MutualChapInherited = types.NewBool(cmd.inherit)
+	} else if cmd.inherit {
+		return nil, fmt.Errorf("-inherit requires -target")
+	}
+
+	if cmd.disable || cmd.inherit {
+		return props, nil
+	}
+
+	level, ok := chapTypes[strings.ToLower(cmd.level)]
+	if !ok {
+		return nil, fmt.Errorf("invalid CHAP level: %s", cmd.level)
+	}
+
+	if level != types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited {
+		if cmd.name == "" {
+			return nil, fmt.Errorf("-name is required")
+		}
+
+		props.ChapAuthEnabled = true
+		props.ChapName = cmd.name
+		props.ChapSecret = cmd.secret
+	}
+
+	props.ChapAuthenticationType = string(level)
+
+	if cmd.mname != "" {
+		// Mutual CHAP can only be required or prohibited
+		if level != types.HostInternetScsiHbaChapAuthenticationTypeChapRequired {
+			return nil, fmt.Errorf("mutual CHAP requires -level required")
+		}
+
+		props.MutualChapName = cmd.mname
+		props.MutualChapSecret = cmd.msecret
+		props.MutualChapAuthenticationType = string(types.HostInternetScsiHbaChapAuthenticationTypeChapRequired)
+	}
+
+	return props, nil
+}
+
+func (cmd *chap) targetSet() (*types.HostInternetScsiHbaTargetSet, error) {
+	if cmd.target == "" {
+		return nil, nil
+	}
+
+	address, port, err := parseAddress(cmd.target)
+	if err != nil {
+		return nil, err
+	}
+
+	set := new(types.HostInternetScsiHbaTargetSet)
+
+	if cmd.iqn == "" {
+		set.SendTargets = []types.HostInternetScsiHbaSendTarget{
+			{Address: address, Port: port},
+		}
+	} else {
+		set.StaticTargets = []types.HostInternetScsiHbaStaticTarget{
+			{Address: address, Port: port, IScsiName: cmd.iqn},
+		}
+	}
+
+	return set, nil
+}
+
+func (cmd *chap) Run(ctx context.Context, f *flag.FlagSet) error {
+	props, err := cmd.properties()
+	if err != nil {
+		return err
+	}
+
+	set, err := cmd.targetSet()
+	if err != nil {
+		return err
+	}
+
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	ss, hba, err := iscsiHba(ctx, host, cmd.hba)
+	if err != nil {
+		return err
+	}
+
+	return ss.UpdateInternetScsiAuthenticationProperties(ctx, hba.Device, *props, set)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iscsi
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"golang.org/x/net/context"
+)
+
+type enable struct {
+	*flags.HostSystemFlag
+
+	enabled bool
+}
+
+func init() {
+	cli.Register("host.storage.iscsi.enable", &enable{enabled: true})
+	cli.Register("host.storage.iscsi.disable", &enable{enabled: false})
+}
+
+func (cmd *enable) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+}
+
+func (cmd *enable) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *enable) Description() string {
+	return `Enable or disable the software iSCSI adapter.
+
+Examples:
+  govc host.storage.iscsi.enable -host hostname
+  govc host.storage.iscsi.disable -host hostname`
+}
+
+func (cmd *enable) Run(ctx context.Context, f *flag.FlagSet) error {
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	ss, err := host.ConfigManager().StorageSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ss.UpdateSoftwareInternetScsiEnabled(ctx, cmd.enabled)
+}
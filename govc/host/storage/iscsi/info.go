@@ -0,0 +1,254 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iscsi
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type info struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+}
+
+func init() {
+	cli.Register("host.storage.iscsi.info", &info{})
+}
+
+func (cmd *info) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+}
+
+func (cmd *info) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *info) Description() string {
+	return `Show iSCSI adapters, their targets and port bindings.
+
+Examples:
+  govc host.storage.iscsi.info -host hostname
+  govc host.storage.iscsi.info -json -host hostname`
+}
+
+// storageSystem returns the host's storage system and its properties.
+func storageSystem(ctx context.Context, host *object.HostSystem) (*object.HostStorageSystem, *mo.HostStorageSystem, error) {
+	ss, err := host.ConfigManager().StorageSystem(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var hss mo.HostStorageSystem
+	err = ss.Properties(ctx, ss.Reference(), []string{"storageDeviceInfo"}, &hss)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ss, &hss, nil
+}
+
+// iscsiHbas returns the iSCSI adapters of the given storage system.
+func iscsiHbas(hss *mo.HostStorageSystem) []*types.HostInternetScsiHba {
+	var hbas []*types.HostInternetScsiHba
+
+	if hss.StorageDeviceInfo == nil {
+		return nil
+	}
+
+	for _, e := range hss.StorageDeviceInfo.HostBusAdapter {
+		if hba, ok := e.(*types.HostInternetScsiHba); ok {
+			hbas = append(hbas, hba)
+		}
+	}
+
+	return hbas
+}
+
+// iscsiHba returns the iSCSI adapter with the given device name,
+// or the software iSCSI adapter if device is empty.
+func iscsiHba(ctx context.Context, host *object.HostSystem, device string) (*object.HostStorageSystem, *types.HostInternetScsiHba, error) {
+	ss, hss, err := storageSystem(ctx, host)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, hba := range iscsiHbas(hss) {
+		if device == "" {
+			if hba.IsSoftwareBased {
+				return ss, hba, nil
+			}
+			continue
+		}
+
+		if hba.Device == device {
+			return ss, hba, nil
+		}
+	}
+
+	if device == "" {
+		return nil, nil, fmt.Errorf("software iSCSI adapter not found (see host.storage.iscsi.enable)")
+	}
+
+	return nil, nil, fmt.Errorf("iSCSI adapter %s not found", device)
+}
+
+// parseAddress splits ADDRESS[:PORT], a zero port means the default port.
+func parseAddress(s string) (string, int32, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		// No port specified
+		return strings.Trim(s, "[]"), 0, nil
+	}
+
+	n, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port in %q", s)
+	}
+
+	return host, int32(n), nil
+}
+
+func formatAddress(address string, port int32) string {
+	if port == 0 {
+		return address
+	}
+	return net.JoinHostPort(address, strconv.Itoa(int(port)))
+}
+
+type hbaInfo struct {
+	*types.HostInternetScsiHba
+	BoundVnics []types.IscsiPortInfo `json:"boundVnics"`
+}
+
+type infoResult struct {
+	Enabled bool       `json:"softwareInternetScsiEnabled"`
+	Hbas    []*hbaInfo `json:"hbas"`
+}
+
+func (cmd *info) Run(ctx context.Context, f *flag.FlagSet) error {
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	_, hss, err := storageSystem(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	res := infoResult{
+		Enabled: hss.StorageDeviceInfo != nil && hss.StorageDeviceInfo.SoftwareInternetScsiEnabled,
+	}
+
+	var m *object.IscsiManager
+
+	for _, hba := range iscsiHbas(hss) {
+		info := &hbaInfo{HostInternetScsiHba: hba}
+		res.Hbas = append(res.Hbas, info)
+
+		if hba.NetworkBindingSupport == "" || hba.NetworkBindingSupport == types.HostInternetScsiHbaNetworkBindingSupportTypeNotsupported {
+			continue
+		}
+
+		if m == nil {
+			m, err = host.ConfigManager().IscsiManager(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		info.BoundVnics, err = m.QueryBoundVnics(ctx, hba.Device)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cmd.WriteResult(&res)
+}
+
+func (r *infoResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Software iSCSI:\t%s\n", enabledString(r.Enabled))
+
+	for _, hba := range r.Hbas {
+		fmt.Fprintf(tw, "\nDevice:\t%s\n", hba.Device)
+		fmt.Fprintf(tw, "  Model:\t%s\n", hba.Model)
+		fmt.Fprintf(tw, "  Status:\t%s\n", hba.Status)
+		fmt.Fprintf(tw, "  IQN:\t%s\n", hba.IScsiName)
+		if hba.IScsiAlias != "" {
+			fmt.Fprintf(tw, "  Alias:\t%s\n", hba.IScsiAlias)
+		}
+		fmt.Fprintf(tw, "  CHAP:\t%s\n", chapString(&hba.AuthenticationProperties))
+
+		for _, t := range hba.ConfiguredSendTarget {
+			fmt.Fprintf(tw, "  Send target:\t%s\n", formatAddress(t.Address, t.Port))
+		}
+
+		for _, t := range hba.ConfiguredStaticTarget {
+			fmt.Fprintf(tw, "  Static target:\t%s %s\n", formatAddress(t.Address, t.Port), t.IScsiName)
+		}
+
+		for _, p := range hba.BoundVnics {
+			fmt.Fprintf(tw, "  Bound vnic:\t%s (%s, %s)\n", p.VnicDevice, p.PortgroupName, p.PathStatus)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func enabledString(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func chapString(p *types.HostInternetScsiHbaAuthenticationProperties) string {
+	if !p.ChapAuthEnabled {
+		return "disabled"
+	}
+
+	s := fmt.Sprintf("%s (%s)", p.ChapName, p.ChapAuthenticationType)
+	if p.MutualChapName != "" {
+		s += fmt.Sprintf(", mutual %s (%s)", p.MutualChapName, p.MutualChapAuthenticationType)
+	}
+
+	return s
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iscsi
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type target struct {
+	*flags.HostSystemFlag
+
+	add    bool
+	hba    string
+	iqn    string
+	rescan bool
+}
+
+func init() {
+	cli.Register("host.storage.iscsi.target.add", &target{add: true})
+	cli.Register("host.storage.iscsi.target.remove", &target{add: false})
+}
+
+func (cmd *target) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.hba, "hba", "", "iSCSI adapter device name, defaults to the software adapter")
+	f.StringVar(&cmd.iqn, "iqn", "", "Target IQN, for static targets")
+	f.BoolVar(&cmd.rescan, "rescan", true, "Rescan all adapters after updating targets")
+}
+
+func (cmd *target) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *target) Usage() string {
+	return "ADDRESS[:PORT]..."
+}
+
+func (cmd *target) Description() string {
+	return `Add or remove iSCSI targets.
+
+Without the -iqn flag, ADDRESS is used as a dynamic discovery (send) target.
+With the -iqn flag, ADDRESS is used as a static target for the given IQN.
+
+Examples:
+  govc host.storage.iscsi.target.add -host hostname 10.0.0.5 10.0.0.6:3260
+  govc host.storage.iscsi.target.add -host hostname -iqn iqn.2016-01.com.example:disk1 10.0.0.5
+  govc host.storage.iscsi.target.remove -host hostname -hba vmhba33 10.0.0.6:3260`
+}
+
+func (cmd *target) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	host, err := cmd.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	ss, hba, err := iscsiHba(ctx, host, cmd.hba)
+	if err != nil {
+		return err
+	}
+
+	var send []types.HostInternetScsiHbaSendTarget
+	var static []types.HostInternetScsiHbaStaticTarget
+
+	for _, arg := range f.Args() {
+		address, port, err := parseAddress(arg)
+		if err != nil {
+			return err
+		}
+
+		if cmd.iqn == "" {
+			send = append(send, types.HostInternetScsiHbaSendTarget{
+				Address: address,
+				Port:    port,
+			})
+		} else {
+			static = append(static, types.HostInternetScsiHbaStaticTarget{
+				Address:   address,
+				Port:      port,
+				IScsiName: cmd.iqn,
+			})
+		}
+	}
+
+	switch {
+	case cmd.add && send != nil:
+		err = ss.AddInternetScsiSendTargets(ctx, hba.Device, send)
+	case cmd.add:
+		err = ss.AddInternetScsiStaticTargets(ctx, hba.Device, static)
+	case send != nil:
+		err = ss.RemoveInternetScsiSendTargets(ctx, hba.Device, send)
+	default:
+		err = ss.RemoveInternetScsiStaticTargets(ctx, hba.Device, static)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.rescan {
+		return ss.RescanAllHba(ctx)
+	}
+
+	return nil
+}
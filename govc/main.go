@@ -22,9 +22,15 @@ import (
 	"github.com/RotatingFans/govmomi/govc/cli"
 
 	_ "github.com/RotatingFans/govmomi/govc/about"
+	_ "github.com/RotatingFans/govmomi/govc/batch"
 	_ "github.com/RotatingFans/govmomi/govc/cluster"
+	_ "github.com/RotatingFans/govmomi/govc/completion"
+	_ "github.com/RotatingFans/govmomi/govc/config"
+	_ "github.com/RotatingFans/govmomi/govc/credential"
+	_ "github.com/RotatingFans/govmomi/govc/customization"
 	_ "github.com/RotatingFans/govmomi/govc/datacenter"
 	_ "github.com/RotatingFans/govmomi/govc/datastore"
+	_ "github.com/RotatingFans/govmomi/govc/datastore/disk"
 	_ "github.com/RotatingFans/govmomi/govc/device"
 	_ "github.com/RotatingFans/govmomi/govc/device/cdrom"
 	_ "github.com/RotatingFans/govmomi/govc/device/floppy"
@@ -36,25 +42,37 @@ import (
 	_ "github.com/RotatingFans/govmomi/govc/events"
 	_ "github.com/RotatingFans/govmomi/govc/extension"
 	_ "github.com/RotatingFans/govmomi/govc/fields"
+	_ "github.com/RotatingFans/govmomi/govc/find"
 	_ "github.com/RotatingFans/govmomi/govc/folder"
 	_ "github.com/RotatingFans/govmomi/govc/host"
 	_ "github.com/RotatingFans/govmomi/govc/host/account"
+	_ "github.com/RotatingFans/govmomi/govc/host/ad"
 	_ "github.com/RotatingFans/govmomi/govc/host/autostart"
+	_ "github.com/RotatingFans/govmomi/govc/host/cert"
+	_ "github.com/RotatingFans/govmomi/govc/host/config"
+	_ "github.com/RotatingFans/govmomi/govc/host/date"
 	_ "github.com/RotatingFans/govmomi/govc/host/esxcli"
 	_ "github.com/RotatingFans/govmomi/govc/host/firewall"
 	_ "github.com/RotatingFans/govmomi/govc/host/maintenance"
+	_ "github.com/RotatingFans/govmomi/govc/host/module"
 	_ "github.com/RotatingFans/govmomi/govc/host/option"
 	_ "github.com/RotatingFans/govmomi/govc/host/portgroup"
+	_ "github.com/RotatingFans/govmomi/govc/host/power"
+	_ "github.com/RotatingFans/govmomi/govc/host/profile"
 	_ "github.com/RotatingFans/govmomi/govc/host/service"
+	_ "github.com/RotatingFans/govmomi/govc/host/snmp"
 	_ "github.com/RotatingFans/govmomi/govc/host/storage"
+	_ "github.com/RotatingFans/govmomi/govc/host/storage/iscsi"
 	_ "github.com/RotatingFans/govmomi/govc/host/vnic"
 	_ "github.com/RotatingFans/govmomi/govc/host/vswitch"
 	_ "github.com/RotatingFans/govmomi/govc/importx"
 	_ "github.com/RotatingFans/govmomi/govc/license"
 	_ "github.com/RotatingFans/govmomi/govc/logs"
 	_ "github.com/RotatingFans/govmomi/govc/ls"
+	_ "github.com/RotatingFans/govmomi/govc/object"
 	_ "github.com/RotatingFans/govmomi/govc/permissions"
 	_ "github.com/RotatingFans/govmomi/govc/pool"
+	_ "github.com/RotatingFans/govmomi/govc/tree"
 	_ "github.com/RotatingFans/govmomi/govc/vapp"
 	_ "github.com/RotatingFans/govmomi/govc/version"
 	_ "github.com/RotatingFans/govmomi/govc/vm"
@@ -0,0 +1,302 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/property"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type collect struct {
+	*flags.DatacenterFlag
+
+	single bool
+	n      int
+}
+
+func init() {
+	cli.Register("object.collect", &collect{})
+}
+
+func (cmd *collect) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.single, "s", false, "Output property value only")
+	f.IntVar(&cmd.n, "n", 0, "Wait for N property updates")
+}
+
+func (cmd *collect) Process(ctx context.Context) error {
+	if err := cmd.DatacenterFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *collect) Usage() string {
+	return "[PATH|MOREF] [PROPERTY]..."
+}
+
+func (cmd *collect) Description() string {
+	return `Collect managed object properties.
+
+PATH can be an inventory path or a managed object reference, and defaults to the current
+datacenter or root folder.  If no PROPERTY is given, all properties of the object are collected.
+The output lists the name, type and value of each property; nested data objects are shown as '...'.
+
+When '-n' is given, the first N property updates are printed, where the first update
+contains the current property values.
+
+Examples:
+  govc object.collect
+  govc object.collect /dc1/vm/vm1 config.hardware.numCPU runtime.powerState
+  govc object.collect -s HostSystem:ha-host summary.runtime.powerState
+  govc object.collect -json ServiceInstance:ServiceInstance content.about
+  govc object.collect -n 3 /dc1/vm/vm1 runtime.powerState`
+}
+
+func (cmd *collect) ref(ctx context.Context, arg string) (types.ManagedObjectReference, error) {
+	var ref types.ManagedObjectReference
+
+	if ref.FromString(arg) {
+		return ref, nil
+	}
+
+	finder, err := cmd.Finder()
+	if err != nil {
+		return ref, err
+	}
+
+	es, err := finder.ManagedObjectList(ctx, arg)
+	if err != nil {
+		return ref, err
+	}
+
+	switch len(es) {
+	case 0:
+		return ref, fmt.Errorf("%s not found", arg)
+	case 1:
+		return es[0].Object.Reference(), nil
+	default:
+		return ref, fmt.Errorf("%s matches %d objects", arg, len(es))
+	}
+}
+
+func (cmd *collect) Run(ctx context.Context, f *flag.FlagSet) error {
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	arg := "."
+	var ps []string
+
+	if f.NArg() != 0 {
+		arg = f.Arg(0)
+		ps = f.Args()[1:]
+	}
+
+	if len(ps) == 0 {
+		ps = nil
+	}
+
+	ref, err := cmd.ref(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	pc := property.DefaultCollector(c)
+
+	if cmd.n > 0 {
+		n := 0
+		werr := error(nil)
+
+		err = property.Wait(ctx, pc, ref, ps, func(changes []types.PropertyChange) bool {
+			var props []types.DynamicProperty
+
+			for _, change := range changes {
+				props = append(props, types.DynamicProperty{Name: change.Name, Val: change.Val})
+			}
+
+			werr = cmd.WriteResult(&collectResult{cmd: cmd, props: props})
+			n++
+
+			return werr != nil || n == cmd.n
+		})
+		if err != nil {
+			return err
+		}
+
+		return werr
+	}
+
+	req := types.RetrieveProperties{
+		This: pc.Reference(),
+		SpecSet: []types.PropertyFilterSpec{
+			{
+				ObjectSet: []types.ObjectSpec{
+					{Obj: ref},
+				},
+				PropSet: []types.PropertySpec{
+					{
+						Type:    ref.Type,
+						PathSet: ps,
+						All:     types.NewBool(ps == nil),
+					},
+				},
+			},
+		},
+	}
+
+	res, err := methods.RetrieveProperties(ctx, c, &req)
+	if err != nil {
+		return err
+	}
+
+	if len(res.Returnval) == 0 {
+		return errors.New("no properties returned")
+	}
+
+	content := res.Returnval[0]
+	if len(content.MissingSet) != 0 {
+		fault := content.MissingSet[0]
+		return fmt.Errorf("%s: %s", fault.Path, fault.Fault.LocalizedMessage)
+	}
+
+	result := &collectResult{cmd: cmd, props: content.PropSet}
+
+	if cmd.JSON || cmd.Dump {
+		result.object, err = mo.ObjectContentToType(content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cmd.WriteResult(result)
+}
+
+type collectResult struct {
+	cmd    *collect
+	props  []types.DynamicProperty
+	object interface{}
+}
+
+func (r *collectResult) MarshalJSON() ([]byte, error) {
+	if r.object != nil {
+		return json.Marshal(r.object)
+	}
+	return json.Marshal(r.props)
+}
+
+func (r *collectResult) Write(w io.Writer) error {
+	props := r.props
+	sort.Sort(byName(props))
+
+	if r.cmd.single {
+		for _, p := range props {
+			fmt.Fprintln(w, formatValue(p.Val))
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, p := range props {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Name, typeName(p.Val), formatValue(p.Val))
+	}
+
+	return tw.Flush()
+}
+
+type byName []types.DynamicProperty
+
+func (s byName) Len() int           { return len(s) }
+func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+// typeName returns the vim25 type name of a property value.
+func typeName(val interface{}) string {
+	if val == nil {
+		return "-"
+	}
+
+	t := reflect.TypeOf(val)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Name() == "" {
+		return t.String()
+	}
+
+	return t.Name()
+}
+
+// formatValue returns a one line representation of a property value.
+// Arrays are joined with ',' and data objects are shown as '...'.
+func formatValue(val interface{}) string {
+	if val == nil {
+		return "-"
+	}
+
+	if ref, ok := val.(types.ManagedObjectReference); ok {
+		return ref.String()
+	}
+
+	rval := reflect.ValueOf(val)
+	if rval.Kind() == reflect.Ptr {
+		if rval.IsNil() {
+			return "-"
+		}
+		rval = rval.Elem()
+	}
+
+	switch rval.Kind() {
+	case reflect.Struct:
+		// ArrayOf* types wrap a single slice field
+		if rval.NumField() == 1 && rval.Field(0).Kind() == reflect.Slice {
+			return formatValue(rval.Field(0).Interface())
+		}
+		if rval.Type().Name() == "Time" {
+			return fmt.Sprint(rval.Interface())
+		}
+		return "..."
+	case reflect.Slice:
+		var items []string
+		for i := 0; i < rval.Len(); i++ {
+			items = append(items, formatValue(rval.Index(i).Interface()))
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(rval.Interface())
+	}
+}
@@ -14,6 +14,25 @@ load test_helper
   assert_success
 }
 
+@test "output formats" {
+  run govc about -o yaml
+  assert_success
+  assert_line "  Vendor: VMware, Inc."
+
+  run govc about -select About.Vendor
+  assert_success "VMware, Inc."
+
+  run govc about -o 'template={{.About.ApiType}}'
+  assert_success
+
+  run govc about -select About -o csv=Vendor,ApiType
+  assert_success
+  assert_line "Vendor,ApiType"
+
+  run govc about -o xml
+  assert_failure
+}
+
 @test "version" {
     run govc version
     assert_success
@@ -67,3 +86,91 @@ load test_helper
   run govc env -u "user:${password}@enoent:99999" GOVC_PASSWORD
   assert_output "$password"
 }
+
+@test "context" {
+  export GOVC_CONFIG=$($mktemp -d)/config
+
+  run govc context.ls
+  assert_success ""
+
+  url=$GOVC_URL
+  unset GOVC_URL GOVC_DATASTORE GOVC_NETWORK
+
+  run govc context.create -url "$url" -ds datastore1 -use test
+  assert_success
+
+  run govc context.create -url "$url" test
+  assert_failure
+
+  run govc context.show
+  assert_success
+  assert_line "GOVC_DATASTORE=datastore1"
+  refute_line "GOVC_PASSWORD=vagrant"
+
+  run govc context.ls
+  assert_line "*  test  $(govc env GOVC_URL)"
+
+  run env GOVC_PASSWORD=vagrant govc about
+  assert_success
+
+  run env GOVC_CONTEXT=enoent govc about
+  assert_failure
+
+  run govc context.rm test
+  assert_success
+
+  run govc context.use test
+  assert_failure
+
+  rm -rf "$(dirname "$GOVC_CONFIG")"
+}
+
+@test "completion" {
+  run govc completion bash
+  assert_success
+  assert_line "complete -F _govc govc"
+
+  run govc completion fish
+  assert_success
+
+  run govc completion ksh
+  assert_failure
+
+  run govc completion.path -- /
+  assert_success
+  assert_line "/ha-datacenter/"
+
+  run govc completion.path -type ds -- ""
+  assert_success
+  assert_line "datastore1"
+
+  run govc completion.path -type dc -- ""
+  assert_success "ha-datacenter/"
+}
+
+@test "batch" {
+  run govc batch <<EOF
+# comment
+about
+ls /
+EOF
+  assert_success
+  assert_line "Vendor: VMware, Inc."
+
+  run govc batch -parallel 2 -json <<EOF
+about
+vm.info -vm enoent
+no.such.command
+EOF
+  assert_failure
+  assert_line "govc: line 3: unknown command: no.such.command"
+
+  # command output must not be mixed with the batch result
+  result=$(printf "about\nls /\n" | govc batch -json 2>/dev/null | jq -r '.[].Status' | sort -u)
+  assert_equal "0" "$result"
+
+  run govc batch <<EOF
+ls "/unterminated
+EOF
+  assert_failure
+}
@@ -105,3 +105,33 @@ upload_file() {
   run govc datastore.ls "${name}"
   assert_failure
 }
+
+@test "datastore.disk" {
+  vm=$(new_empty_vm)
+
+  run govc vm.disk.create -vm $vm -name $vm/disk1 -size 10M
+  assert_success
+
+  run govc device.remove -vm $vm -keep disk-1000-0
+  assert_success
+
+  run govc datastore.disk.info enoent.vmdk
+  assert_failure
+
+  run govc datastore.disk.info $vm/disk1.vmdk
+  assert_success
+  assert_line "Name: [${GOVC_DATASTORE##*/}] $vm/disk1.vmdk"
+
+  run govc datastore.disk.extend -size 20M $vm/disk1.vmdk
+  assert_success
+
+  run govc datastore.disk.inflate $vm/disk1.vmdk
+  assert_success
+
+  run govc datastore.disk.uuid -uuid "60 00 c2 9b 69 2f 16 2a-2c 8d 07 ab 3f 0e 8a 8c" $vm/disk1.vmdk
+  assert_success
+
+  run govc datastore.disk.uuid $vm/disk1.vmdk
+  assert_success
+  assert_matches "^60 00 [cC]2 9b 69 2f 16 2a" "$output"
+}
@@ -0,0 +1,41 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "find" {
+  vm=$(new_empty_vm)
+
+  run govc find -type m
+  assert_success
+  assert_line "/ha-datacenter/vm/$vm"
+
+  run govc find / -type m -name "$vm" -runtime.powerState poweredOff
+  assert_success "/ha-datacenter/vm/$vm"
+
+  run govc find / -type m -name "$vm" -runtime.powerState poweredOn
+  assert_success ""
+
+  run govc find -type m -name "$vm" -runtime.powerState poweredOff
+  assert_success "/ha-datacenter/vm/$vm"
+
+  run govc find -runtime.powerState poweredOff
+  assert_failure "govc: property filters require -type"
+
+  run govc find . -type m -name "$vm" -config.hardware.numCPU '>=1'
+  assert_success "/ha-datacenter/vm/$vm"
+
+  run govc find -i . -type m -name "$vm"
+  assert_success "$(govc ls -i "vm/$vm")"
+
+  run govc find -maxdepth 1 /ha-datacenter
+  assert_success
+  assert_line "/ha-datacenter/vm"
+  refute_line "/ha-datacenter/vm/$vm"
+
+  run govc find / -type s -summary.accessible true
+  assert_success
+  assert_line "/ha-datacenter/datastore/$GOVC_DATASTORE"
+
+  run govc find / -type
+  assert_failure
+}
@@ -104,3 +104,273 @@ load test_helper
     run govc host.service status TSM-SSH
     assert_success
 }
+
+@test "host.date" {
+    run govc host.date.info
+    assert_success
+
+    run govc host.date.info -json
+    assert_success
+
+    run govc host.date.info -tz
+    assert_success
+
+    run govc host.date.change -date now
+    assert_success
+
+    run govc host.date.info -max-skew 1m
+    assert_success
+
+    run govc host.date.change -date yesterday
+    assert_failure
+
+    run govc host.date.change -ntpd maybe
+    assert_failure
+}
+
+@test "host.cert" {
+    run govc host.cert.info
+    assert_success
+
+    run govc host.cert.info -json
+    assert_success
+
+    run govc host.cert.info -ca
+    assert_success
+
+    run govc host.cert.csr
+    assert_success
+    assert_line "-----BEGIN CERTIFICATE REQUEST-----"
+
+    run govc host.cert.csr -ip
+    assert_success
+
+    run govc host.cert.import
+    assert_failure
+
+    run govc host.cert.import "$BATS_TEST_FILENAME"
+    assert_failure
+}
+
+@test "host.storage.iscsi" {
+    run govc host.storage.iscsi.enable
+    assert_success
+
+    run govc host.storage.iscsi.info
+    assert_success
+    assert_line "Software iSCSI: enabled"
+
+    run govc host.storage.iscsi.info -json
+    assert_success
+
+    run govc host.storage.iscsi.target.add -rescan=false 127.0.0.1:3260
+    assert_success
+
+    run govc host.storage.iscsi.info
+    assert_success
+    assert_line "Send target: 127.0.0.1:3260"
+
+    run govc host.storage.iscsi.chap -name govc
+    assert_success
+
+    run govc host.storage.iscsi.chap -inherit
+    assert_failure
+
+    run govc host.storage.iscsi.chap -level maybe -name govc
+    assert_failure
+
+    run govc host.storage.iscsi.chap -disable
+    assert_success
+
+    run govc host.storage.iscsi.target.remove -rescan=false 127.0.0.1:3260
+    assert_success
+
+    run govc host.storage.iscsi.bind
+    assert_failure
+
+    run govc host.storage.iscsi.disable
+    assert_success
+}
+
+@test "host.config" {
+    local bundle=$BATS_TMPDIR/$(new_id).tgz
+
+    run govc host.config.backup "$bundle"
+    assert_success
+    [ -s "$bundle" ]
+
+    run govc host.config.restore
+    assert_failure
+
+    # host is not in maintenance mode
+    run govc host.config.restore "$bundle"
+    assert_failure
+
+    rm -f "$bundle"
+}
+
+@test "host.profile" {
+    vcsim_env
+
+    run govc host.profile.ls
+    assert_success
+
+    name=$(new_id)
+
+    run govc host.profile.create -host "$GOVC_HOST" "$name"
+    assert_success
+
+    run govc host.profile.ls
+    assert_success
+    assert_matches "$name" "$output"
+
+    run govc host.profile.attach "$name"
+    assert_failure
+
+    run govc host.profile.attach "$name" "$GOVC_HOST"
+    assert_success
+
+    run govc host.profile.check -profile "$name"
+    assert_success
+
+    run govc host.profile.check -json -profile "$name"
+    assert_success
+
+    run govc host.profile.apply -plan -profile "$name" "$GOVC_HOST"
+    assert_success
+
+    run govc host.profile.export "$name"
+    assert_success
+
+    run govc host.profile.detach "$name"
+    assert_success
+
+    run govc host.profile.rm "$name"
+    assert_success
+
+    run govc host.profile.rm "$name"
+    assert_failure
+}
+
+@test "host.power.policy" {
+    run govc host.power.policy
+    assert_success
+    assert_matches '^ *\* ' "$output"
+
+    run govc host.power.policy -json
+    assert_success
+
+    run govc host.power.policy -set enoent
+    assert_failure
+
+    # types.HostPowerPolicy has no json tags
+    current=$(govc host.power.policy -json | jq -r '.[0].current.ShortName')
+    [ -n "$current" ] && [ "$current" != "null" ]
+
+    run govc host.power.policy -set static
+    assert_success
+
+    run govc host.power.policy -set "$current"
+    assert_success
+}
+
+@test "host.reboot" {
+    run govc host.reboot
+    assert_failure
+
+    run govc host.shutdown
+    assert_failure
+}
+
+@test "host.ad" {
+    run govc host.ad.info
+    assert_success
+
+    run govc host.ad.info -json
+    assert_success
+
+    run govc host.ad.join
+    assert_failure
+
+    run govc host.ad.join example.com
+    assert_failure
+
+    run govc host.ad.join -username enoent -password enoent enoent.example.com
+    assert_failure
+}
+
+@test "host.snmp" {
+    run govc host.snmp.info
+    assert_success
+
+    run govc host.snmp.info -json
+    assert_success
+
+    run govc host.snmp.change -target enoent
+    assert_failure
+
+    run govc host.snmp.change -option enoent
+    assert_failure
+
+    run govc host.snmp.change -community govc-test -target 127.0.0.1/govc-test
+    assert_success
+
+    run govc host.snmp.info
+    assert_success
+    assert_line "Communities: govc-test"
+    assert_line "Trap targets: 127.0.0.1:162/govc-test"
+
+    run govc host.snmp.change -community "" -target ""
+    assert_success
+
+    run govc host.snmp.info
+    assert_success
+    assert_matches "Communities: *$" "$output"
+}
+
+@test "host.module" {
+    run govc host.module.ls
+    assert_success
+
+    run govc host.module.ls -loaded -json
+    assert_success
+
+    run govc host.module.ls enoent
+    assert_failure
+
+    name=$(govc host.module.ls -loaded -json | jq -r '.[0].Name')
+
+    run govc host.module.ls "$name"
+    assert_success
+
+    run govc host.module.option
+    assert_failure
+
+    run govc host.module.option "$name"
+    assert_success
+
+    options=$(govc host.module.option -json "$name" | jq -r '.[0].options')
+
+    run govc host.module.option -set "$options" "$name"
+    assert_success
+}
+
+@test "host.health" {
+    run govc host.health
+    assert_success
+
+    run govc host.health -refresh -json
+    assert_success
+
+    run govc host.health -type sensor -status green,yellow,red
+    assert_success
+
+    run govc host.health -type memory -json
+    assert_success
+
+    run govc host.health -type temperature,fan
+    assert_success
+
+    kinds=$(govc host.health -type temperature,fan -json | jq -r '.[].type' | sort -u)
+    [ -z "$kinds" ] || [ "$kinds" = "sensor" ]
+}
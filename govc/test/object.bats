@@ -0,0 +1,29 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "object.collect" {
+  vm=$(new_empty_vm)
+
+  run govc object.collect "vm/$vm" config.name runtime.powerState
+  assert_success
+  assert_line "config.name  string  $vm"
+
+  run govc object.collect -s "vm/$vm" runtime.powerState
+  assert_success "poweredOff"
+
+  run govc object.collect -s "$(govc ls -i "vm/$vm")" config.name
+  assert_success "$vm"
+
+  run govc object.collect -json "vm/$vm" config.name
+  assert_success
+
+  run govc object.collect -n 1 -s "vm/$vm" runtime.powerState
+  assert_success "poweredOff"
+
+  run govc object.collect "vm/$vm" enoent
+  assert_failure
+
+  run govc object.collect /enoent
+  assert_failure
+}
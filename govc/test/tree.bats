@@ -0,0 +1,40 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "tree" {
+  vm=$(new_empty_vm)
+
+  run govc tree
+  assert_success
+  assert_line "/"
+  assert_line "└── ha-datacenter"
+
+  run govc tree -L 1
+  assert_success
+  refute_line "$vm"
+
+  run govc tree -i /ha-datacenter/vm
+  assert_success
+  assert_matches "── $vm ($(govc ls -i "vm/$vm"))" "$output"
+
+  run govc tree /enoent
+  assert_failure
+}
+
+@test "tree -export" {
+  vm=$(new_empty_vm)
+
+  run govc tree -export
+  assert_success
+
+  path=$(govc tree -export | jq -r ".inventory[] | select(.path == \"/ha-datacenter/vm/$vm\") | .type")
+  [ "$path" = "VirtualMachine" ]
+
+  state=$(govc tree -export | jq -r ".inventory[] | select(.path == \"/ha-datacenter/vm/$vm\") | .properties[\"runtime.powerState\"]")
+  [ "$state" = "poweredOff" ]
+
+  run govc tree -export -o yaml -select inventory /ha-datacenter/vm
+  assert_success
+  assert_line "- path: /ha-datacenter/vm/$vm"
+}
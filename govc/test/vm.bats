@@ -79,6 +79,120 @@ load test_helper
   [ ${#lines[@]} -gt 0 ]
 }
 
+@test "vm.apply" {
+  id=$(new_id)
+  spec=$BATS_TMPDIR/$id.yaml
+
+  cat > "$spec" <<EOF
+name: $id
+cpus: 1
+memory: 512
+disks:
+  - size: 1GB
+nics:
+  - network: VM Network
+EOF
+
+  run govc vm.apply -f "$spec" -plan
+  assert_success
+  assert_matches "^+ vm: *$id$" "$output"
+
+  run govc vm.info $id
+  [ ${#lines[@]} -eq 0 ]
+
+  run govc vm.apply -f "$spec"
+  assert_success
+
+  run govc vm.info $id
+  assert_success
+  assert_line "Memory: 512MB"
+
+  run govc vm.apply -f "$spec"
+  assert_success "No changes."
+
+  cat > "$spec" <<EOF
+name: $id
+cpus: 2
+memory: 1024
+disks:
+  - size: 2GB
+  - size: 1GB
+nics:
+  - {}
+extraConfig:
+  guestinfo.role: test
+  guestinfo.enabled: true
+  guestinfo.count: 0
+EOF
+
+  run govc vm.apply -f "$spec"
+  assert_success
+  assert_matches "^~ cpus: *1 => 2$" "$output"
+
+  run govc vm.info -e $id
+  assert_success
+  assert_line "CPU: 2 vCPU(s)"
+  assert_line "Memory: 1024MB"
+  assert_line "guestinfo.role: test"
+  assert_line "guestinfo.enabled: true"
+  assert_line "guestinfo.count: 0"
+
+  run govc device.ls -vm $id disk-*
+  [ ${#lines[@]} -eq 2 ]
+
+  run govc vm.apply -f "$spec"
+  assert_success "No changes."
+
+  # the same spec as JSON
+  json=$BATS_TMPDIR/$id.json
+  cat > "$json" <<EOF
+{
+  "name": "$id",
+  "cpus": 2,
+  "memory": 1024,
+  "disks": [{"size": "2GB"}, {"size": "1GB"}],
+  "nics": [{}],
+  "extraConfig": {"guestinfo.role": "test", "guestinfo.enabled": true, "guestinfo.count": 0}
+}
+EOF
+
+  run govc vm.apply -f "$json"
+  assert_success "No changes."
+  rm -f "$json"
+
+  sed -i 's/size: 2GB/size: 1GB/' "$spec"
+  run govc vm.apply -f "$spec"
+  assert_failure
+
+  rm -f "$spec"
+}
+
+@test "vm.destroy -dry-run" {
+  vm=$(new_empty_vm)
+
+  run govc vm.destroy -dry-run $vm
+  assert_success
+  assert_line "# dry-run: Destroy_Task"
+
+  run govc vm.destroy -dry-run=json $vm
+  assert_success
+  assert_line "# dry-run: Destroy_Task"
+
+  # requests are logged to stderr, leaving stdout for command output
+  govc vm.destroy -dry-run $vm 2>/dev/null | assert_empty
+
+  run govc vm.info -dry-run $vm
+  assert_success
+  assert_line "Name: $vm"
+
+  run govc vm.info $vm
+  assert_success
+  assert_line "Name: $vm"
+
+  run govc vm.destroy -dry-run=yaml $vm
+  assert_failure
+}
+
 @test "vm.power" {
   vm=$(new_ttylinux_vm)
 
@@ -358,6 +472,31 @@ load test_helper
   [ $result -eq 2 ]
 }
 
+@test "vm.disk.change" {
+  vm=$(new_empty_vm)
+
+  local name=$(new_id)
+
+  run govc vm.disk.create -vm $vm -name $name -size 1G
+  assert_success
+
+  run govc vm.disk.change -vm $vm -disk.name disk-1000-0
+  assert_failure "govc: please specify a new disk size"
+
+  run govc vm.disk.change -vm $vm -disk.name enoent -size 2G
+  assert_failure "govc: no disk found using the given values"
+
+  run govc vm.disk.change -vm $vm -disk.name disk-1000-0 -size 512M
+  assert_failure
+
+  run govc vm.disk.change -vm $vm -disk.name disk-1000-0 -size 2G
+  assert_success
+
+  run govc device.info -vm $vm -json disk-1000-0
+  assert_success
+  [ $(jq -r .Devices[].CapacityInKB <<<"$output") -eq 2097152 ]
+}
+
 @test "vm.disk.attach" {
   import_ttylinux_vmdk
 
@@ -0,0 +1,347 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/list"
+	"github.com/RotatingFans/govmomi/property"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// containers are the types descended into when walking the inventory.
+// HostSystem is not included, as its datastore, network and vm children are
+// already listed under their own folders.
+var containers = map[string]bool{
+	"Folder":                 true,
+	"StoragePod":             true,
+	"Datacenter":             true,
+	"ComputeResource":        true,
+	"ClusterComputeResource": true,
+	"ResourcePool":           true,
+	"VirtualApp":             true,
+}
+
+// exportProperties are the key properties included with -export, by type.
+var exportProperties = map[string][]string{
+	"ClusterComputeResource": {"summary.numHosts", "summary.numEffectiveHosts", "summary.totalCpu", "summary.totalMemory"},
+	"Datastore":              {"summary.type", "summary.url", "summary.capacity", "summary.freeSpace", "summary.accessible"},
+	"HostSystem":             {"runtime.connectionState", "runtime.powerState", "runtime.inMaintenanceMode", "summary.config.product.fullName"},
+	"Network":                {"summary.accessible"},
+	"VirtualMachine":         {"config.uuid", "config.guestId", "config.template", "config.hardware.numCPU", "config.hardware.memoryMB", "runtime.powerState", "guest.ipAddress"},
+}
+
+type tree struct {
+	*flags.DatacenterFlag
+
+	depth  int
+	ref    bool
+	export bool
+}
+
+func init() {
+	cli.Register("tree", &tree{})
+}
+
+func (cmd *tree) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+
+	f.IntVar(&cmd.depth, "L", 0, "Max display depth of the tree, 0 for no limit")
+	f.BoolVar(&cmd.ref, "i", false, "Print the managed object reference of each node")
+	f.BoolVar(&cmd.export, "export", false, "Export inventory paths, types, references and key properties")
+}
+
+func (cmd *tree) Process(ctx context.Context) error {
+	if err := cmd.DatacenterFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *tree) Usage() string {
+	return "[PATH]..."
+}
+
+func (cmd *tree) Description() string {
+	return `Print the inventory hierarchy below PATH as a tree, PATH defaults to the root folder.
+
+Datacenters, folders, clusters, resource pools and vApps are descended into.
+
+When '-export' is given, every object in the tree is written with its inventory path,
+type, managed object reference and key properties, sorted by path.  The output format
+defaults to JSON and can be changed with the '-o' flag, for example to YAML.
+
+Examples:
+  govc tree
+  govc tree -L 2 -i /dc1
+  govc tree /dc1/host
+  govc tree -export > inventory-$(date +%F).json
+  govc tree -export -o yaml /dc1/vm`
+}
+
+type node struct {
+	list.Element
+	Children []*node
+}
+
+func (cmd *tree) walk(ctx context.Context, r list.Recurser, n *node, depth int) error {
+	if !containers[n.Object.Reference().Type] {
+		return nil
+	}
+
+	if cmd.depth > 0 && depth >= cmd.depth {
+		return nil
+	}
+
+	// Children are listed as the "*" pattern is by 'govc ls'
+	es, err := r.Recurse(ctx, n.Element, []string{"*"})
+	if err != nil {
+		return err
+	}
+
+	sort.Sort(byPath(es))
+
+	for _, e := range es {
+		child := &node{Element: e}
+
+		if err = cmd.walk(ctx, r, child, depth+1); err != nil {
+			return err
+		}
+
+		n.Children = append(n.Children, child)
+	}
+
+	return nil
+}
+
+func (cmd *tree) Run(ctx context.Context, f *flag.FlagSet) error {
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	finder, err := cmd.Finder()
+	if err != nil {
+		return err
+	}
+
+	args := f.Args()
+	if len(args) == 0 {
+		args = []string{"/"}
+	}
+
+	pc := property.DefaultCollector(c)
+	r := list.Recurser{Collector: pc}
+
+	var roots []*node
+
+	for _, arg := range args {
+		es, err := finder.ManagedObjectList(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if len(es) == 0 {
+			return fmt.Errorf("%s not found", arg)
+		}
+
+		for _, e := range es {
+			root := &node{Element: e}
+
+			if err = cmd.walk(ctx, r, root, 0); err != nil {
+				return err
+			}
+
+			roots = append(roots, root)
+		}
+	}
+
+	if cmd.export {
+		cmd.JSON = true // default export format
+		return cmd.exportResult(ctx, pc, roots)
+	}
+
+	return cmd.WriteResult(&treeResult{cmd: cmd, Roots: roots})
+}
+
+type treeResult struct {
+	cmd   *tree
+	Roots []*node `json:"roots"`
+}
+
+func (r *treeResult) Write(w io.Writer) error {
+	for _, root := range r.Roots {
+		fmt.Fprintln(w, r.label(root, root.Path))
+		r.write(w, root, "")
+	}
+
+	return nil
+}
+
+func (r *treeResult) label(n *node, name string) string {
+	if r.cmd.ref {
+		return fmt.Sprintf("%s (%s)", name, n.Object.Reference())
+	}
+	return name
+}
+
+func (r *treeResult) write(w io.Writer, n *node, indent string) {
+	for i, child := range n.Children {
+		branch, next := "├── ", "│   "
+		if i == len(n.Children)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		fmt.Fprintf(w, "%s%s%s\n", indent, branch, r.label(child, path.Base(child.Path)))
+		r.write(w, child, indent+next)
+	}
+}
+
+type entry struct {
+	Path       string                 `json:"path"`
+	Type       string                 `json:"type"`
+	Reference  string                 `json:"ref"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type exportResult struct {
+	Inventory []*entry `json:"inventory"`
+}
+
+func (cmd *tree) exportResult(ctx context.Context, pc *property.Collector, roots []*node) error {
+	var res exportResult
+	seen := make(map[types.ManagedObjectReference]*entry)
+	refs := make(map[string][]types.ManagedObjectReference)
+
+	var add func(*node)
+	add = func(n *node) {
+		ref := n.Object.Reference()
+
+		if _, ok := seen[ref]; !ok {
+			e := &entry{
+				Path:      n.Path,
+				Type:      ref.Type,
+				Reference: ref.String(),
+			}
+
+			seen[ref] = e
+			res.Inventory = append(res.Inventory, e)
+			refs[ref.Type] = append(refs[ref.Type], ref)
+		}
+
+		for _, child := range n.Children {
+			add(child)
+		}
+	}
+
+	for _, root := range roots {
+		add(root)
+	}
+
+	kinds := make([]string, 0, len(refs))
+	for kind := range refs {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		ps, ok := exportProperties[kind]
+		if !ok {
+			continue
+		}
+
+		spec := types.PropertyFilterSpec{
+			PropSet: []types.PropertySpec{{Type: kind, PathSet: ps}},
+		}
+
+		for _, ref := range refs[kind] {
+			spec.ObjectSet = append(spec.ObjectSet, types.ObjectSpec{Obj: ref})
+		}
+
+		req := types.RetrieveProperties{SpecSet: []types.PropertyFilterSpec{spec}}
+
+		content, err := pc.RetrieveProperties(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range content.Returnval {
+			e := seen[o.Obj]
+			if e == nil || len(o.PropSet) == 0 {
+				continue
+			}
+
+			e.Properties = make(map[string]interface{})
+			for _, p := range o.PropSet {
+				e.Properties[p.Name] = exportValue(p.Val)
+			}
+		}
+	}
+
+	sort.Sort(byEntryPath(res.Inventory))
+
+	return cmd.WriteResult(&res)
+}
+
+func (r *exportResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, e := range r.Inventory {
+		fmt.Fprintf(tw, "%s\t%s\n", e.Path, e.Reference)
+	}
+
+	return tw.Flush()
+}
+
+// exportValue converts a property value to a plain value for encoding,
+// references are encoded as strings.
+func exportValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case types.ManagedObjectReference:
+		return v.String()
+	case types.ArrayOfManagedObjectReference:
+		var refs []string
+		for _, ref := range v.ManagedObjectReference {
+			refs = append(refs, ref.String())
+		}
+		return refs
+	}
+
+	return val
+}
+
+type byPath []list.Element
+
+func (s byPath) Len() int           { return len(s) }
+func (s byPath) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byPath) Less(i, j int) bool { return s[i].Path < s[j].Path }
+
+type byEntryPath []*entry
+
+func (s byEntryPath) Len() int           { return len(s) }
+func (s byEntryPath) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byEntryPath) Less(i, j int) bool { return s[i].Path < s[j].Path }
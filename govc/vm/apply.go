@@ -0,0 +1,691 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/find"
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/units"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// applySpec is the desired state of a VM, as read by vm.apply.
+type applySpec struct {
+	Name           string                 `json:"name"`
+	GuestID        string                 `json:"guestId"`
+	CPUs           int32                  `json:"cpus"`
+	CoresPerSocket int32                  `json:"coresPerSocket"`
+	Memory         int64                  `json:"memory"`
+	Annotation     *string                `json:"annotation"`
+	Controller     string                 `json:"controller"`
+	Disks          []applyDisk            `json:"disks"`
+	NICs           []applyNIC             `json:"nics"`
+	ExtraConfig    map[string]interface{} `json:"extraConfig"`
+	Options        applyOptions           `json:"options"`
+}
+
+type applyDisk struct {
+	Size      string `json:"size"`
+	Datastore string `json:"datastore"`
+	Mode      string `json:"mode"`
+	Thick     bool   `json:"thick"`
+	Eager     bool   `json:"eager"`
+}
+
+type applyNIC struct {
+	Network string `json:"network"`
+	Adapter string `json:"adapter"`
+	MAC     string `json:"mac"`
+}
+
+type applyOptions struct {
+	NestedHV     *bool  `json:"nestedHV"`
+	CPUHotAdd    *bool  `json:"cpuHotAdd"`
+	MemoryHotAdd *bool  `json:"memoryHotAdd"`
+	Firmware     string `json:"firmware"`
+}
+
+type apply struct {
+	*flags.DatacenterFlag
+	*flags.DatastoreFlag
+	*flags.ResourcePoolFlag
+	*flags.HostSystemFlag
+	*flags.FolderFlag
+
+	file  string
+	plan  bool
+	prune bool
+
+	creating bool
+	spec     applySpec
+	config   types.VirtualMachineConfigSpec
+	devices  object.VirtualDeviceList
+	changes  []applyChange
+}
+
+func init() {
+	cli.Register("vm.apply", &apply{})
+}
+
+func (cmd *apply) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+
+	cmd.DatastoreFlag, ctx = flags.NewDatastoreFlag(ctx)
+	cmd.DatastoreFlag.Register(ctx, f)
+
+	cmd.ResourcePoolFlag, ctx = flags.NewResourcePoolFlag(ctx)
+	cmd.ResourcePoolFlag.Register(ctx, f)
+
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+
+	cmd.FolderFlag, ctx = flags.NewFolderFlag(ctx)
+	cmd.FolderFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.file, "f", "", "VM spec file (YAML or JSON), '-' for stdin")
+	f.BoolVar(&cmd.plan, "plan", false, "Print the plan only, do not apply changes")
+	f.BoolVar(&cmd.prune, "prune", false, "Remove disks and NICs that are not in the spec")
+}
+
+func (cmd *apply) Process(ctx context.Context) error {
+	if err := cmd.DatacenterFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.DatastoreFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.ResourcePoolFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	if err := cmd.FolderFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *apply) Description() string {
+	return `Apply the VM spec read from FILE.
+
+If the VM does not exist it is created, otherwise its CPU, memory, disks, NICs, extraConfig
+and options are compared with the spec and all changes are applied with a single reconfigure.
+The plan of changes is printed before they are applied, applying the same spec again is a no-op.
+
+Disks and NICs are matched to the VM's devices by order.  Disks can grow but not shrink.
+Devices beyond those in the spec are left as-is, unless '-prune' is given, in which case
+they are removed (disk files are kept).  The '-ds', '-pool', '-host' and '-folder' flags
+are used only when the VM is created.
+
+A NIC without a network is attached to the default network when added, the network of an
+existing NIC is changed only if given in the spec.  The spec can also be given as JSON.
+
+Example spec:
+  name: web-01
+  guestId: otherGuest64
+  cpus: 2
+  memory: 4096 # MB
+  disks:
+    - size: 20GB
+  nics:
+    - network: VM Network
+      adapter: vmxnet3
+  extraConfig:
+    guestinfo.role: frontend
+    disk.EnableUUID: true
+  options:
+    cpuHotAdd: true
+
+Examples:
+  govc vm.apply -f web-01.yaml -plan
+  govc vm.apply -f web-01.yaml
+  govc vm.apply -f web-01.json -prune`
+}
+
+func (cmd *apply) Run(ctx context.Context, f *flag.FlagSet) error {
+	if cmd.file == "" {
+		return flag.ErrHelp
+	}
+
+	if err := cmd.load(); err != nil {
+		return err
+	}
+
+	finder, err := cmd.Finder()
+	if err != nil {
+		return err
+	}
+
+	vm, err := finder.VirtualMachine(ctx, cmd.spec.Name)
+	if err != nil {
+		if _, ok := err.(*find.NotFoundError); !ok {
+			return err
+		}
+	}
+
+	var config *types.VirtualMachineConfigInfo
+
+	if vm != nil {
+		var o mo.VirtualMachine
+
+		err = vm.Properties(ctx, vm.Reference(), []string{"config"}, &o)
+		if err != nil {
+			return err
+		}
+
+		if o.Config == nil {
+			return fmt.Errorf("%s: config is not available", cmd.spec.Name)
+		}
+
+		config = o.Config
+	}
+
+	if err = cmd.diff(ctx, config); err != nil {
+		return err
+	}
+
+	if err = cmd.WriteResult(&applyResult{cmd.changes}); err != nil {
+		return err
+	}
+
+	if cmd.plan || len(cmd.changes) == 0 {
+		return nil
+	}
+
+	var task *object.Task
+
+	if vm == nil {
+		task, err = cmd.create(ctx)
+	} else {
+		task, err = vm.Reconfigure(ctx, cmd.config)
+	}
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
+
+func (cmd *apply) load() error {
+	var r io.Reader = os.Stdin
+
+	if cmd.file != "-" {
+		f, err := os.Open(cmd.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := flags.DecodeYAML(r, &cmd.spec); err != nil {
+		return fmt.Errorf("%s: %s", cmd.file, err)
+	}
+
+	if cmd.spec.Name == "" {
+		return errors.New("spec name is required")
+	}
+
+	for key, val := range cmd.spec.ExtraConfig {
+		switch val.(type) {
+		case string, bool, json.Number:
+		default:
+			return fmt.Errorf("extraConfig.%s: value must be a string, number or boolean", key)
+		}
+	}
+
+	return nil
+}
+
+type applyChange struct {
+	Op   string `json:"op"`
+	Item string `json:"item"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+func (cmd *apply) add(item string, to interface{}) {
+	cmd.changes = append(cmd.changes, applyChange{"+", item, "", fmt.Sprint(to)})
+}
+
+func (cmd *apply) modify(item string, from, to interface{}) {
+	if cmd.creating {
+		cmd.add(item, to)
+		return
+	}
+	cmd.changes = append(cmd.changes, applyChange{"~", item, fmt.Sprint(from), fmt.Sprint(to)})
+}
+
+func (cmd *apply) remove(item string, from interface{}) {
+	cmd.changes = append(cmd.changes, applyChange{"-", item, fmt.Sprint(from), ""})
+}
+
+// diff populates the config spec and plan of changes, config is nil if the VM does not exist.
+func (cmd *apply) diff(ctx context.Context, config *types.VirtualMachineConfigInfo) error {
+	spec := cmd.spec
+	current := types.VirtualMachineConfigInfo{}
+
+	if config == nil {
+		cmd.creating = true
+
+		if spec.GuestID == "" {
+			spec.GuestID = "otherGuest"
+		}
+		if spec.CPUs == 0 {
+			spec.CPUs = 1
+		}
+		if spec.Memory == 0 {
+			spec.Memory = 1024
+		}
+
+		cmd.add("vm", spec.Name)
+		cmd.config.Name = spec.Name
+
+		scsi, err := cmd.devices.CreateSCSIController(spec.Controller)
+		if err != nil {
+			return err
+		}
+		cmd.addDevice(scsi)
+	} else {
+		current = *config
+		cmd.devices = object.VirtualDeviceList(config.Hardware.Device)
+	}
+
+	if spec.GuestID != "" && spec.GuestID != current.GuestId {
+		cmd.config.GuestId = spec.GuestID
+		cmd.modify("guestId", current.GuestId, spec.GuestID)
+	}
+
+	if spec.CPUs != 0 && spec.CPUs != current.Hardware.NumCPU {
+		cmd.config.NumCPUs = spec.CPUs
+		cmd.modify("cpus", current.Hardware.NumCPU, spec.CPUs)
+	}
+
+	if spec.CoresPerSocket != 0 && spec.CoresPerSocket != current.Hardware.NumCoresPerSocket {
+		cmd.config.NumCoresPerSocket = spec.CoresPerSocket
+		cmd.modify("coresPerSocket", current.Hardware.NumCoresPerSocket, spec.CoresPerSocket)
+	}
+
+	if spec.Memory != 0 && spec.Memory != int64(current.Hardware.MemoryMB) {
+		cmd.config.MemoryMB = spec.Memory
+		cmd.modify("memory", current.Hardware.MemoryMB, spec.Memory)
+	}
+
+	if spec.Annotation != nil && *spec.Annotation != current.Annotation {
+		cmd.config.Annotation = *spec.Annotation
+		cmd.modify("annotation", strconv.Quote(current.Annotation), strconv.Quote(*spec.Annotation))
+	}
+
+	cmd.diffOptions(current)
+	cmd.diffExtraConfig(current.ExtraConfig)
+
+	if err := cmd.diffDisks(ctx); err != nil {
+		return err
+	}
+
+	return cmd.diffNICs(ctx)
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+func (cmd *apply) diffOptions(current types.VirtualMachineConfigInfo) {
+	opts := cmd.spec.Options
+
+	if opts.NestedHV != nil && *opts.NestedHV != boolValue(current.NestedHVEnabled) {
+		cmd.config.NestedHVEnabled = opts.NestedHV
+		cmd.modify("options.nestedHV", boolValue(current.NestedHVEnabled), *opts.NestedHV)
+	}
+
+	if opts.CPUHotAdd != nil && *opts.CPUHotAdd != boolValue(current.CpuHotAddEnabled) {
+		cmd.config.CpuHotAddEnabled = opts.CPUHotAdd
+		cmd.modify("options.cpuHotAdd", boolValue(current.CpuHotAddEnabled), *opts.CPUHotAdd)
+	}
+
+	if opts.MemoryHotAdd != nil && *opts.MemoryHotAdd != boolValue(current.MemoryHotAddEnabled) {
+		cmd.config.MemoryHotAddEnabled = opts.MemoryHotAdd
+		cmd.modify("options.memoryHotAdd", boolValue(current.MemoryHotAddEnabled), *opts.MemoryHotAdd)
+	}
+
+	if opts.Firmware != "" && opts.Firmware != current.Firmware {
+		cmd.config.Firmware = opts.Firmware
+		cmd.modify("options.firmware", current.Firmware, opts.Firmware)
+	}
+}
+
+func (cmd *apply) diffExtraConfig(current []types.BaseOptionValue) {
+	values := make(map[string]string)
+	for _, o := range current {
+		opt := o.GetOptionValue()
+		values[opt.Key] = fmt.Sprint(opt.Value)
+	}
+
+	var keys []string
+	for key := range cmd.spec.ExtraConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := fmt.Sprint(cmd.spec.ExtraConfig[key])
+		cur, ok := values[key]
+		if ok && cur == val {
+			continue
+		}
+
+		cmd.config.ExtraConfig = append(cmd.config.ExtraConfig, &types.OptionValue{Key: key, Value: val})
+
+		item := "extraConfig." + key
+		if ok {
+			cmd.modify(item, cur, val)
+		} else {
+			cmd.add(item, val)
+		}
+	}
+}
+
+func (cmd *apply) addDevice(device types.BaseVirtualDevice) {
+	cmd.devices = append(cmd.devices, device)
+	cmd.deviceChange(device, types.VirtualDeviceConfigSpecOperationAdd, "")
+}
+
+func (cmd *apply) deviceChange(device types.BaseVirtualDevice, op types.VirtualDeviceConfigSpecOperation, fop types.VirtualDeviceConfigSpecFileOperation) {
+	cmd.config.DeviceChange = append(cmd.config.DeviceChange, &types.VirtualDeviceConfigSpec{
+		Operation:     op,
+		FileOperation: fop,
+		Device:        device,
+	})
+}
+
+func (cmd *apply) diffDisks(ctx context.Context) error {
+	disks := cmd.devices.SelectByType((*types.VirtualDisk)(nil))
+
+	for i, d := range cmd.spec.Disks {
+		var size units.ByteSize
+		if err := size.Set(d.Size); err != nil {
+			return fmt.Errorf("disks[%d]: invalid size %q", i, d.Size)
+		}
+		kb := int64(size) / 1024
+
+		if i < len(disks) {
+			disk := disks[i].(*types.VirtualDisk)
+			name := cmd.devices.Name(disk)
+
+			switch {
+			case kb < disk.CapacityInKB:
+				return fmt.Errorf("%s: cannot shrink disk from %s to %s", name, units.ByteSize(disk.CapacityInKB*1024), size)
+			case kb > disk.CapacityInKB:
+				cmd.modify(name+".size", units.ByteSize(disk.CapacityInKB*1024), size)
+				disk.CapacityInKB = kb
+				cmd.deviceChange(disk, types.VirtualDeviceConfigSpecOperationEdit, "")
+			}
+
+			continue
+		}
+
+		controller, err := cmd.devices.FindDiskController("")
+		if err != nil {
+			return err
+		}
+
+		mode := d.Mode
+		if mode == "" {
+			mode = string(types.VirtualDiskModePersistent)
+		}
+
+		backing := &types.VirtualDiskFlatVer2BackingInfo{
+			DiskMode:        mode,
+			ThinProvisioned: types.NewBool(!d.Thick),
+		}
+
+		if d.Eager {
+			backing.EagerlyScrub = types.NewBool(true)
+		}
+
+		// Without a datastore, the disk is created in the VM's directory
+		if d.Datastore != "" {
+			finder, err := cmd.Finder()
+			if err != nil {
+				return err
+			}
+
+			datastore, err := finder.Datastore(ctx, d.Datastore)
+			if err != nil {
+				return err
+			}
+
+			ref := datastore.Reference()
+			backing.Datastore = &ref
+			backing.FileName = fmt.Sprintf("[%s]", datastore.Name())
+		}
+
+		disk := &types.VirtualDisk{
+			VirtualDevice: types.VirtualDevice{
+				Key:     cmd.devices.NewKey(),
+				Backing: backing,
+			},
+			CapacityInKB: kb,
+		}
+
+		cmd.devices.AssignController(disk, controller)
+		cmd.devices = append(cmd.devices, disk)
+		cmd.deviceChange(disk, types.VirtualDeviceConfigSpecOperationAdd, types.VirtualDeviceConfigSpecFileOperationCreate)
+		cmd.add(fmt.Sprintf("disk[%d]", i), size)
+	}
+
+	if cmd.prune {
+		for _, disk := range disks[min(len(disks), len(cmd.spec.Disks)):] {
+			cmd.remove(cmd.devices.Name(disk), units.ByteSize(disk.(*types.VirtualDisk).CapacityInKB*1024))
+			cmd.deviceChange(disk, types.VirtualDeviceConfigSpecOperationRemove, "")
+		}
+	}
+
+	return nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// backingName returns the network name or portgroup key of an ethernet card backing.
+func backingName(backing types.BaseVirtualDeviceBackingInfo) string {
+	switch b := backing.(type) {
+	case *types.VirtualEthernetCardNetworkBackingInfo:
+		return b.DeviceName
+	case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+		return b.Port.PortgroupKey
+	default:
+		return fmt.Sprintf("%T", backing)
+	}
+}
+
+func (cmd *apply) diffNICs(ctx context.Context) error {
+	nics := cmd.devices.SelectByType((*types.VirtualEthernetCard)(nil))
+
+	finder, err := cmd.Finder()
+	if err != nil {
+		return err
+	}
+
+	networkBacking := func(name string) (types.BaseVirtualDeviceBackingInfo, error) {
+		net, err := finder.NetworkOrDefault(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return net.EthernetCardBackingInfo(ctx)
+	}
+
+	for i, n := range cmd.spec.NICs {
+		if i < len(nics) {
+			nic := nics[i]
+			name := cmd.devices.Name(nic)
+			card := nic.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+
+			if n.Adapter == "" || n.Adapter == cmd.devices.Type(nic) {
+				edit := false
+
+				// Without a network in the spec, the NIC is left on its current network
+				if n.Network != "" {
+					backing, err := networkBacking(n.Network)
+					if err != nil {
+						return err
+					}
+
+					if from, to := backingName(card.Backing), backingName(backing); from != to {
+						cmd.modify(name+".network", from, to)
+						card.Backing = backing
+						edit = true
+					}
+				}
+
+				if n.MAC != "" && n.MAC != card.MacAddress {
+					cmd.modify(name+".mac", card.MacAddress, n.MAC)
+					card.AddressType = string(types.VirtualEthernetCardMacTypeManual)
+					card.MacAddress = n.MAC
+					edit = true
+				}
+
+				if edit {
+					cmd.deviceChange(nic, types.VirtualDeviceConfigSpecOperationEdit, "")
+				}
+
+				continue
+			}
+
+			// The adapter type cannot be changed, replace the device
+			cmd.remove(name, cmd.devices.Type(nic))
+			cmd.deviceChange(nic, types.VirtualDeviceConfigSpecOperationRemove, "")
+		}
+
+		backing, err := networkBacking(n.Network)
+		if err != nil {
+			return err
+		}
+
+		adapter := n.Adapter
+		if adapter == "" {
+			adapter = "e1000"
+		}
+
+		device, err := object.EthernetCardTypes().CreateEthernetCard(adapter, backing)
+		if err != nil {
+			return err
+		}
+
+		card := device.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		card.Key = cmd.devices.NewKey()
+
+		if n.MAC != "" {
+			card.AddressType = string(types.VirtualEthernetCardMacTypeManual)
+			card.MacAddress = n.MAC
+		}
+
+		cmd.addDevice(device)
+		cmd.add(fmt.Sprintf("nic[%d]", i), fmt.Sprintf("%s %s", adapter, backingName(backing)))
+	}
+
+	if cmd.prune {
+		for _, nic := range nics[min(len(nics), len(cmd.spec.NICs)):] {
+			card := nic.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+			cmd.remove(cmd.devices.Name(nic), backingName(card.Backing))
+			cmd.deviceChange(nic, types.VirtualDeviceConfigSpecOperationRemove, "")
+		}
+	}
+
+	return nil
+}
+
+func (cmd *apply) create(ctx context.Context) (*object.Task, error) {
+	datastore, err := cmd.DatastoreFlag.Datastore()
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := cmd.HostSystemFlag.HostSystemIfSpecified()
+	if err != nil {
+		return nil, err
+	}
+
+	var pool *object.ResourcePool
+
+	if host != nil {
+		pool, err = host.ResourcePool(ctx)
+	} else {
+		pool, err = cmd.ResourcePoolFlag.ResourcePool()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := cmd.FolderFlag.Folder()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.config.Files = &types.VirtualMachineFileInfo{
+		VmPathName: fmt.Sprintf("[%s]", datastore.Name()),
+	}
+
+	return folder.CreateVM(ctx, cmd.config, pool, host)
+}
+
+type applyResult struct {
+	Changes []applyChange `json:"changes"`
+}
+
+func (r *applyResult) Write(w io.Writer) error {
+	if len(r.Changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	for _, c := range r.Changes {
+		switch c.Op {
+		case "+":
+			fmt.Fprintf(tw, "%s %s:\t%s\n", c.Op, c.Item, c.To)
+		case "-":
+			fmt.Fprintf(tw, "%s %s:\t%s\n", c.Op, c.Item, c.From)
+		default:
+			fmt.Fprintf(tw, "%s %s:\t%s => %s\n", c.Op, c.Item, c.From, c.To)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/units"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type change struct {
+	*flags.VirtualMachineFlag
+
+	key      int
+	label    string
+	name     string
+	filePath string
+
+	bytes units.ByteSize
+}
+
+func init() {
+	cli.Register("vm.disk.change", &change{})
+}
+
+func (cmd *change) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.VirtualMachineFlag, ctx = flags.NewVirtualMachineFlag(ctx)
+	cmd.VirtualMachineFlag.Register(ctx, f)
+
+	f.IntVar(&cmd.key, "disk.key", 0, "Disk unique key")
+	f.StringVar(&cmd.label, "disk.label", "", "Disk label")
+	f.StringVar(&cmd.name, "disk.name", "", "Disk name")
+	f.StringVar(&cmd.filePath, "disk.filePath", "", "Disk file name")
+	f.Var(&cmd.bytes, "size", "New disk size")
+}
+
+func (cmd *change) Process(ctx context.Context) error {
+	if err := cmd.VirtualMachineFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *change) Description() string {
+	return `Change some properties of a VM's DISK.
+
+The disk can be selected by key, label, name or file path.
+Growing a disk is supported while the VM is powered on, shrinking is not supported.
+The guest OS must extend its partition and filesystem to use the new capacity.
+
+Examples:
+  govc vm.disk.change -vm $vm -disk.name disk-1000-0 -size 20G
+  govc vm.disk.change -vm $vm -disk.label "Hard disk 2" -size 100G
+  govc vm.disk.change -vm $vm -disk.filePath "[datastore1] $vm/${vm}_1.vmdk" -size 1T`
+}
+
+func (cmd *change) findDisk(devices object.VirtualDeviceList) (*types.VirtualDisk, error) {
+	var disks []*types.VirtualDisk
+
+	for _, device := range devices.SelectByType((*types.VirtualDisk)(nil)) {
+		disk := device.(*types.VirtualDisk)
+		d := disk.GetVirtualDevice()
+
+		if cmd.key != 0 && d.Key != int32(cmd.key) {
+			continue
+		}
+
+		if cmd.label != "" && (d.DeviceInfo == nil || d.DeviceInfo.GetDescription().Label != cmd.label) {
+			continue
+		}
+
+		if cmd.name != "" && devices.Name(disk) != cmd.name {
+			continue
+		}
+
+		if cmd.filePath != "" {
+			b, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo)
+			if !ok || b.GetVirtualDeviceFileBackingInfo().FileName != cmd.filePath {
+				continue
+			}
+		}
+
+		disks = append(disks, disk)
+	}
+
+	switch len(disks) {
+	case 0:
+		return nil, errors.New("no disk found using the given values")
+	case 1:
+		return disks[0], nil
+	default:
+		var names []string
+		for _, disk := range disks {
+			names = append(names, devices.Name(disk))
+		}
+		return nil, fmt.Errorf("%d disks match the given values: %s", len(disks), strings.Join(names, ", "))
+	}
+}
+
+func (cmd *change) Run(ctx context.Context, f *flag.FlagSet) error {
+	vm, err := cmd.VirtualMachine()
+	if err != nil {
+		return err
+	}
+
+	if vm == nil {
+		return flag.ErrHelp
+	}
+
+	if cmd.bytes == 0 {
+		return errors.New("please specify a new disk size")
+	}
+
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return err
+	}
+
+	disk, err := cmd.findDisk(devices)
+	if err != nil {
+		return err
+	}
+
+	return vm.ExtendDisk(ctx, disk, int64(cmd.bytes)/1024)
+}
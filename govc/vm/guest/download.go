@@ -18,17 +18,21 @@ package guest
 
 import (
 	"flag"
-
 	"os"
 
 	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/progress"
+	"github.com/RotatingFans/govmomi/vim25/soap"
 	"golang.org/x/net/context"
 )
 
 type download struct {
 	*GuestFlag
+	*flags.OutputFlag
 
 	overwrite bool
+	recursive bool
 }
 
 func init() {
@@ -38,18 +42,43 @@ func init() {
 func (cmd *download) Register(ctx context.Context, f *flag.FlagSet) {
 	cmd.GuestFlag, ctx = newGuestFlag(ctx)
 	cmd.GuestFlag.Register(ctx, f)
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
 
 	f.BoolVar(&cmd.overwrite, "f", false, "If set, the local destination file is clobbered")
+	f.BoolVar(&cmd.recursive, "r", false, "Recursively download the SOURCE directory")
 }
 
 func (cmd *download) Process(ctx context.Context) error {
 	if err := cmd.GuestFlag.Process(ctx); err != nil {
 		return err
 	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
 	return nil
 }
 
+func (cmd *download) Usage() string {
+	return "SOURCE DEST"
+}
+
+func (cmd *download) Description() string {
+	return `Copy SOURCE from the guest VM to DEST on the local system.
+
+If the -r flag is given, SOURCE is a directory that is downloaded recursively,
+creating DEST and any sub directories on the local system as needed.
+
+Examples:
+  govc guest.download -vm $name -l user:pass /var/log/app.log ./app.log
+  govc guest.download -vm $name -l user:pass -r /var/log/app ./logs`
+}
+
 func (cmd *download) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 2 {
+		return flag.ErrHelp
+	}
+
 	m, err := cmd.FileManager()
 	if err != nil {
 		return err
@@ -58,25 +87,26 @@ func (cmd *download) Run(ctx context.Context, f *flag.FlagSet) error {
 	src := f.Arg(0)
 	dst := f.Arg(1)
 
-	_, err = os.Stat(dst)
-	if err == nil && !cmd.overwrite {
-		return os.ErrExist
+	if !cmd.recursive {
+		_, err = os.Stat(dst)
+		if err == nil && !cmd.overwrite {
+			return os.ErrExist
+		}
 	}
 
-	info, err := m.InitiateFileTransferFromGuest(context.TODO(), cmd.Auth(), src)
-	if err != nil {
-		return err
+	var s progress.Sinker
+	if cmd.OutputFlag.TTY {
+		logger := cmd.ProgressLogger("Downloading... ")
+		s = logger
+		defer logger.Wait()
 	}
 
-	u, err := cmd.ParseURL(info.Url)
-	if err != nil {
-		return err
+	if cmd.recursive {
+		return m.DownloadDirectory(ctx, cmd.Auth(), src, dst, cmd.overwrite, s)
 	}
 
-	c, err := cmd.Client()
-	if err != nil {
-		return nil
-	}
+	p := soap.DefaultDownload
+	p.Progress = s
 
-	return c.Client.DownloadFile(dst, u, nil)
+	return m.DownloadFile(ctx, cmd.Auth(), src, dst, &p)
 }
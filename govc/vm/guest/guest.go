@@ -62,7 +62,7 @@ func (flag *GuestFlag) Process(ctx context.Context) error {
 	return nil
 }
 
-func (flag *GuestFlag) FileManager() (*guest.FileManager, error) {
+func (flag *GuestFlag) OperationsManager() (*guest.OperationsManager, error) {
 	c, err := flag.Client()
 	if err != nil {
 		return nil, err
@@ -73,22 +73,24 @@ func (flag *GuestFlag) FileManager() (*guest.FileManager, error) {
 		return nil, err
 	}
 
-	o := guest.NewOperationsManager(c, vm.Reference())
-	return o.FileManager(context.TODO())
+	return guest.NewOperationsManager(c, vm.Reference()), nil
 }
 
-func (flag *GuestFlag) ProcessManager() (*guest.ProcessManager, error) {
-	c, err := flag.Client()
+func (flag *GuestFlag) FileManager() (*guest.FileManager, error) {
+	o, err := flag.OperationsManager()
 	if err != nil {
 		return nil, err
 	}
 
-	vm, err := flag.VirtualMachine()
+	return o.FileManager(context.TODO())
+}
+
+func (flag *GuestFlag) ProcessManager() (*guest.ProcessManager, error) {
+	o, err := flag.OperationsManager()
 	if err != nil {
 		return nil, err
 	}
 
-	o := guest.NewOperationsManager(c, vm.Reference())
 	return o.ProcessManager(context.TODO())
 }
 
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/guest"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+var regKeyWowTypes = []string{
+	string(types.GuestRegKeyWowSpecWOWNative),
+	string(types.GuestRegKeyWowSpecWOW32),
+	string(types.GuestRegKeyWowSpecWOW64),
+}
+
+// RegistryFlag is a GuestFlag with options for Windows guest registry commands.
+type RegistryFlag struct {
+	*GuestFlag
+
+	wow string
+}
+
+func newRegistryFlag(ctx context.Context) (*RegistryFlag, context.Context) {
+	f := &RegistryFlag{}
+	f.GuestFlag, ctx = newGuestFlag(ctx)
+	return f, ctx
+}
+
+func (flag *RegistryFlag) Register(ctx context.Context, f *flag.FlagSet) {
+	flag.GuestFlag.Register(ctx, f)
+
+	usage := fmt.Sprintf("Registry view (%s)", strings.Join(regKeyWowTypes, "|"))
+	f.StringVar(&flag.wow, "wow", string(types.GuestRegKeyWowSpecWOWNative), usage)
+}
+
+func (flag *RegistryFlag) Process(ctx context.Context) error {
+	if err := flag.GuestFlag.Process(ctx); err != nil {
+		return err
+	}
+
+	for _, t := range regKeyWowTypes {
+		if flag.wow == t {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid registry view: %s", flag.wow)
+}
+
+func (flag *RegistryFlag) RegistryManager() (*guest.RegistryManager, error) {
+	o, err := flag.OperationsManager()
+	if err != nil {
+		return nil, err
+	}
+
+	return o.RegistryManager(context.TODO())
+}
+
+// KeyName returns the registry key spec for the given path, such as HKEY_LOCAL_MACHINE\SOFTWARE.
+func (flag *RegistryFlag) KeyName(path string) types.GuestRegKeyNameSpec {
+	return types.GuestRegKeyNameSpec{
+		RegistryPath: path,
+		WowBitness:   flag.wow,
+	}
+}
+
+var regValueTypes = []string{"string", "expand", "multi", "dword", "qword", "binary"}
+
+// regValueData converts the given command line values to registry value data of the given type.
+func regValueData(kind string, values []string) (types.BaseGuestRegValueDataSpec, error) {
+	if kind != "multi" && len(values) != 1 {
+		return nil, fmt.Errorf("%s value type requires exactly 1 value", kind)
+	}
+
+	switch kind {
+	case "string":
+		return &types.GuestRegValueStringSpec{Value: values[0]}, nil
+	case "expand":
+		return &types.GuestRegValueExpandStringSpec{Value: values[0]}, nil
+	case "multi":
+		return &types.GuestRegValueMultiStringSpec{Value: values}, nil
+	case "dword":
+		v, err := strconv.ParseUint(values[0], 0, 32)
+		if err != nil {
+			return nil, err
+		}
+		return &types.GuestRegValueDwordSpec{Value: int32(v)}, nil
+	case "qword":
+		v, err := strconv.ParseUint(values[0], 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &types.GuestRegValueQwordSpec{Value: int64(v)}, nil
+	case "binary":
+		v, err := hex.DecodeString(values[0])
+		if err != nil {
+			return nil, err
+		}
+		return &types.GuestRegValueBinarySpec{Value: v}, nil
+	}
+
+	return nil, fmt.Errorf("invalid value type: %s", kind)
+}
+
+// regValueString returns the type and a string representation of the given registry value data.
+func regValueString(data types.BaseGuestRegValueDataSpec) (string, string) {
+	switch v := data.(type) {
+	case *types.GuestRegValueStringSpec:
+		return "string", v.Value
+	case *types.GuestRegValueExpandStringSpec:
+		return "expand", v.Value
+	case *types.GuestRegValueMultiStringSpec:
+		return "multi", strings.Join(v.Value, ",")
+	case *types.GuestRegValueDwordSpec:
+		return "dword", fmt.Sprintf("%#x", uint32(v.Value))
+	case *types.GuestRegValueQwordSpec:
+		return "qword", fmt.Sprintf("%#x", uint64(v.Value))
+	case *types.GuestRegValueBinarySpec:
+		return "binary", hex.EncodeToString(v.Value)
+	}
+
+	return "unknown", ""
+}
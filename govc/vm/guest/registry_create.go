@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"golang.org/x/net/context"
+)
+
+type registryCreate struct {
+	*RegistryFlag
+
+	volatile bool
+	class    string
+}
+
+func init() {
+	cli.Register("guest.registry.create", &registryCreate{})
+}
+
+func (cmd *registryCreate) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.RegistryFlag, ctx = newRegistryFlag(ctx)
+	cmd.RegistryFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.volatile, "volatile", false, "Create a volatile key, which is not preserved across reboots")
+	f.StringVar(&cmd.class, "class", "", "User defined class type of the key")
+}
+
+func (cmd *registryCreate) Process(ctx context.Context) error {
+	if err := cmd.RegistryFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *registryCreate) Usage() string {
+	return "KEY"
+}
+
+func (cmd *registryCreate) Description() string {
+	return `Create registry KEY in a Windows VM.
+
+Examples:
+  govc guest.registry.create -vm $name -l user:pass 'HKEY_LOCAL_MACHINE\SOFTWARE\Example'`
+}
+
+func (cmd *registryCreate) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	m, err := cmd.RegistryManager()
+	if err != nil {
+		return err
+	}
+
+	return m.CreateRegistryKey(ctx, cmd.Auth(), cmd.KeyName(f.Arg(0)), cmd.volatile, cmd.class)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"golang.org/x/net/context"
+)
+
+type registryLs struct {
+	*RegistryFlag
+
+	recursive bool
+	values    bool
+	expand    bool
+	match     string
+}
+
+func init() {
+	cli.Register("guest.registry.ls", &registryLs{})
+}
+
+func (cmd *registryLs) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.RegistryFlag, ctx = newRegistryFlag(ctx)
+	cmd.RegistryFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.recursive, "r", false, "List sub keys recursively")
+	f.BoolVar(&cmd.values, "v", false, "List the values of KEY instead of its sub keys")
+	f.BoolVar(&cmd.expand, "x", false, "Expand environment variables in values of type expand")
+	f.StringVar(&cmd.match, "match", "", "Only list keys or values matching the given regular expression")
+}
+
+func (cmd *registryLs) Process(ctx context.Context) error {
+	if err := cmd.RegistryFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *registryLs) Usage() string {
+	return "KEY"
+}
+
+func (cmd *registryLs) Description() string {
+	return `List sub keys or values of registry KEY in a Windows VM.
+
+Examples:
+  govc guest.registry.ls -vm $name -l user:pass 'HKEY_LOCAL_MACHINE\SOFTWARE\VMware, Inc.'
+  govc guest.registry.ls -vm $name -l user:pass -v 'HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters'`
+}
+
+func (cmd *registryLs) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	m, err := cmd.RegistryManager()
+	if err != nil {
+		return err
+	}
+
+	key := cmd.KeyName(f.Arg(0))
+	tw := tabwriter.NewWriter(os.Stdout, 3, 0, 2, ' ', 0)
+
+	if cmd.values {
+		values, err := m.ListRegistryValues(ctx, cmd.Auth(), key, cmd.expand, cmd.match)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range values {
+			kind, data := regValueString(v.Data)
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Name.Name, kind, data)
+		}
+
+		return tw.Flush()
+	}
+
+	keys, err := m.ListRegistryKeys(ctx, cmd.Auth(), key, cmd.recursive, cmd.match)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if k.Fault != nil {
+			fmt.Fprintf(tw, "%s\t%s\n", k.Key.KeyName.RegistryPath, k.Fault.LocalizedMessage)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", k.Key.KeyName.RegistryPath, k.Key.LastWritten.Format("Mon Jan 2 15:04:05 2006"))
+	}
+
+	return tw.Flush()
+}
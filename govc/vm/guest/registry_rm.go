@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"flag"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type registryRm struct {
+	*RegistryFlag
+
+	recursive bool
+	value     string
+}
+
+func init() {
+	cli.Register("guest.registry.rm", &registryRm{})
+}
+
+func (cmd *registryRm) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.RegistryFlag, ctx = newRegistryFlag(ctx)
+	cmd.RegistryFlag.Register(ctx, f)
+
+	f.BoolVar(&cmd.recursive, "r", false, "Delete KEY and all of its sub keys")
+	f.StringVar(&cmd.value, "value", "", "Delete the named value of KEY, instead of KEY itself")
+}
+
+func (cmd *registryRm) Process(ctx context.Context) error {
+	if err := cmd.RegistryFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *registryRm) Usage() string {
+	return "KEY"
+}
+
+func (cmd *registryRm) Description() string {
+	return `Delete registry KEY or one of its values in a Windows VM.
+
+Examples:
+  govc guest.registry.rm -vm $name -l user:pass -r 'HKEY_LOCAL_MACHINE\SOFTWARE\Example'
+  govc guest.registry.rm -vm $name -l user:pass -value Version 'HKEY_LOCAL_MACHINE\SOFTWARE\Example'`
+}
+
+func (cmd *registryRm) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+
+	m, err := cmd.RegistryManager()
+	if err != nil {
+		return err
+	}
+
+	key := cmd.KeyName(f.Arg(0))
+
+	if cmd.value != "" {
+		name := types.GuestRegValueNameSpec{
+			KeyName: key,
+			Name:    cmd.value,
+		}
+
+		return m.DeleteRegistryValue(ctx, cmd.Auth(), name)
+	}
+
+	return m.DeleteRegistryKey(ctx, cmd.Auth(), key, cmd.recursive)
+}
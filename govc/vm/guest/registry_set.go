@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type registrySet struct {
+	*RegistryFlag
+
+	kind string
+}
+
+func init() {
+	cli.Register("guest.registry.set", &registrySet{})
+}
+
+func (cmd *registrySet) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.RegistryFlag, ctx = newRegistryFlag(ctx)
+	cmd.RegistryFlag.Register(ctx, f)
+
+	usage := fmt.Sprintf("Value type (%s)", strings.Join(regValueTypes, "|"))
+	f.StringVar(&cmd.kind, "t", "string", usage)
+}
+
+func (cmd *registrySet) Process(ctx context.Context) error {
+	if err := cmd.RegistryFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *registrySet) Usage() string {
+	return "KEY NAME VALUE..."
+}
+
+func (cmd *registrySet) Description() string {
+	return `Set value NAME of registry KEY in a Windows VM, creating the value if needed.
+
+Multiple VALUE arguments are only supported with the multi value type.
+The dword and qword types accept decimal or 0x prefixed hex numbers, the binary type a hex string.
+
+Examples:
+  govc guest.registry.set -vm $name -l user:pass 'HKEY_LOCAL_MACHINE\SOFTWARE\Example' Owner ops
+  govc guest.registry.set -vm $name -l user:pass -t dword 'HKEY_LOCAL_MACHINE\SOFTWARE\Example' Enabled 1
+  govc guest.registry.set -vm $name -l user:pass -t multi 'HKEY_LOCAL_MACHINE\SOFTWARE\Example' Servers ntp1 ntp2`
+}
+
+func (cmd *registrySet) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() < 3 {
+		return flag.ErrHelp
+	}
+
+	data, err := regValueData(cmd.kind, f.Args()[2:])
+	if err != nil {
+		return err
+	}
+
+	m, err := cmd.RegistryManager()
+	if err != nil {
+		return err
+	}
+
+	value := types.GuestRegValueSpec{
+		Name: types.GuestRegValueNameSpec{
+			KeyName: cmd.KeyName(f.Arg(0)),
+			Name:    f.Arg(1),
+		},
+		Data: data,
+	}
+
+	return m.SetRegistryValue(ctx, cmd.Auth(), value)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"flag"
+	"os"
+
+	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/guest"
+	"golang.org/x/net/context"
+)
+
+type run struct {
+	*GuestFlag
+
+	dir     string
+	vars    env
+	capture bool
+}
+
+func init() {
+	cli.Register("guest.run", &run{})
+}
+
+func (cmd *run) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.GuestFlag, ctx = newGuestFlag(ctx)
+	cmd.GuestFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.dir, "C", "", "The absolute path of the working directory for the program to start")
+	f.Var(&cmd.vars, "e", "Set environment variable (key=val)")
+	f.BoolVar(&cmd.capture, "capture", true, "Capture the program's stdout and stderr")
+}
+
+func (cmd *run) Process(ctx context.Context) error {
+	if err := cmd.GuestFlag.Process(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *run) Usage() string {
+	return "PATH [ARG]..."
+}
+
+func (cmd *run) Description() string {
+	return `Run program PATH in VM and display output.
+
+The guest.run command starts a program in the VM, waits for it to exit and exits with an
+error if the program exit code is non-zero.  Unlike guest.start, the program's stdout and
+stderr are captured to temporary files in the guest and written to the local stdout and
+stderr once the program exits.  This requires a shell in the guest: /bin/sh or cmd.exe.
+
+Examples:
+  govc guest.run -vm $name -l user:pass /usr/bin/uname -a
+  govc guest.run -vm $name -l user:pass -e PATH=/sbin:/bin -C /tmp /usr/bin/make install
+  govc guest.run -vm $name -l user:pass -capture=false /usr/bin/systemctl restart app`
+}
+
+func (cmd *run) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+
+	m, err := cmd.OperationsManager()
+	if err != nil {
+		return err
+	}
+
+	c := guest.Cmd{
+		Path: f.Arg(0),
+		Args: f.Args()[1:],
+		Dir:  cmd.dir,
+		Env:  cmd.vars,
+	}
+
+	if cmd.capture {
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+	}
+
+	return m.Run(ctx, cmd.Auth(), &c)
+}
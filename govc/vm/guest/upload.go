@@ -21,14 +21,19 @@ import (
 	"os"
 
 	"github.com/RotatingFans/govmomi/govc/cli"
+	"github.com/RotatingFans/govmomi/govc/flags"
+	"github.com/RotatingFans/govmomi/vim25/progress"
+	"github.com/RotatingFans/govmomi/vim25/soap"
 	"golang.org/x/net/context"
 )
 
 type upload struct {
 	*GuestFlag
 	*FileAttrFlag
+	*flags.OutputFlag
 
 	overwrite bool
+	recursive bool
 }
 
 func init() {
@@ -40,8 +45,11 @@ func (cmd *upload) Register(ctx context.Context, f *flag.FlagSet) {
 	cmd.GuestFlag.Register(ctx, f)
 	cmd.FileAttrFlag, ctx = newFileAttrFlag(ctx)
 	cmd.FileAttrFlag.Register(ctx, f)
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
 
 	f.BoolVar(&cmd.overwrite, "f", false, "If set, the guest destination file is clobbered")
+	f.BoolVar(&cmd.recursive, "r", false, "Recursively upload the SOURCE directory")
 }
 
 func (cmd *upload) Process(ctx context.Context) error {
@@ -51,10 +59,32 @@ func (cmd *upload) Process(ctx context.Context) error {
 	if err := cmd.FileAttrFlag.Process(ctx); err != nil {
 		return err
 	}
+	if err := cmd.OutputFlag.Process(ctx); err != nil {
+		return err
+	}
 	return nil
 }
 
+func (cmd *upload) Usage() string {
+	return "SOURCE DEST"
+}
+
+func (cmd *upload) Description() string {
+	return `Copy SOURCE from the local system to DEST in the guest VM.
+
+If the -r flag is given, SOURCE is a directory that is uploaded recursively,
+creating DEST and any sub directories in the guest as needed.
+
+Examples:
+  govc guest.upload -vm $name -l user:pass ./app.conf /etc/app.conf
+  govc guest.upload -vm $name -l user:pass -r -f ./bundle /opt/bundle`
+}
+
 func (cmd *upload) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 2 {
+		return flag.ErrHelp
+	}
+
 	m, err := cmd.FileManager()
 	if err != nil {
 		return err
@@ -68,20 +98,24 @@ func (cmd *upload) Run(ctx context.Context, f *flag.FlagSet) error {
 		return err
 	}
 
-	url, err := m.InitiateFileTransferToGuest(context.TODO(), cmd.Auth(), dst, cmd.Attr(), s.Size(), cmd.overwrite)
-	if err != nil {
-		return err
-	}
+	if cmd.recursive && s.IsDir() {
+		var s progress.Sinker
+		if cmd.OutputFlag.TTY {
+			logger := cmd.ProgressLogger("Uploading... ")
+			s = logger
+			defer logger.Wait()
+		}
 
-	u, err := cmd.ParseURL(url)
-	if err != nil {
-		return err
+		return m.UploadDirectory(ctx, cmd.Auth(), src, dst, cmd.overwrite, s)
 	}
 
-	c, err := cmd.Client()
-	if err != nil {
-		return nil
+	p := soap.DefaultUpload
+	p.ContentLength = s.Size()
+	if cmd.OutputFlag.TTY {
+		logger := cmd.ProgressLogger("Uploading... ")
+		p.Progress = logger
+		defer logger.Wait()
 	}
 
-	return c.Client.UploadFile(src, u, nil)
+	return m.UploadFile(ctx, cmd.Auth(), src, dst, cmd.Attr(), cmd.overwrite, &p)
 }
@@ -0,0 +1,271 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/vim25/progress"
+	"github.com/RotatingFans/govmomi/vim25/soap"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// TransferURL parses a URL returned by InitiateFileTransferToGuest or InitiateFileTransferFromGuest.
+// The '*' host placeholder used by ESX is replaced with the host of the client connection.
+func (m FileManager) TransferURL(u string) (*url.URL, error) {
+	return m.c.Client.ParseURL(u)
+}
+
+// Upload transfers the contents of f to guestFilePath in the guest.
+// The size of f is taken from param.ContentLength, or from f itself if it is an *os.File.
+// If attr is nil, the guest file is created with default attributes.
+func (m FileManager) Upload(ctx context.Context, auth types.BaseGuestAuthentication, f io.Reader, guestFilePath string, attr types.BaseGuestFileAttributes, overwrite bool, param *soap.Upload) error {
+	p := soap.DefaultUpload
+	if param != nil {
+		p = *param // Copy since we set ContentLength
+	}
+
+	if file, ok := f.(*os.File); ok && p.ContentLength == 0 {
+		s, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		p.ContentLength = s.Size()
+	}
+
+	if attr == nil {
+		attr = &types.GuestFileAttributes{}
+	}
+
+	s, err := m.InitiateFileTransferToGuest(ctx, auth, guestFilePath, attr, p.ContentLength, overwrite)
+	if err != nil {
+		return err
+	}
+
+	u, err := m.TransferURL(s)
+	if err != nil {
+		return err
+	}
+
+	return m.c.Client.Upload(f, u, &p)
+}
+
+// UploadFile transfers the local file to guestFilePath in the guest.
+func (m FileManager) UploadFile(ctx context.Context, auth types.BaseGuestAuthentication, file string, guestFilePath string, attr types.BaseGuestFileAttributes, overwrite bool, param *soap.Upload) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return m.Upload(ctx, auth, f, guestFilePath, attr, overwrite, param)
+}
+
+// Download returns a reader for the contents of guestFilePath in the guest, along with its size.
+func (m FileManager) Download(ctx context.Context, auth types.BaseGuestAuthentication, guestFilePath string, param *soap.Download) (io.ReadCloser, int64, error) {
+	if param == nil {
+		param = &soap.DefaultDownload
+	}
+
+	info, err := m.InitiateFileTransferFromGuest(ctx, auth, guestFilePath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	u, err := m.TransferURL(info.Url)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return m.c.Client.Download(u, param)
+}
+
+// DownloadFile transfers guestFilePath from the guest to the local file.
+func (m FileManager) DownloadFile(ctx context.Context, auth types.BaseGuestAuthentication, guestFilePath string, file string, param *soap.Download) error {
+	if param == nil {
+		param = &soap.DefaultDownload
+	}
+
+	info, err := m.InitiateFileTransferFromGuest(ctx, auth, guestFilePath)
+	if err != nil {
+		return err
+	}
+
+	u, err := m.TransferURL(info.Url)
+	if err != nil {
+		return err
+	}
+
+	return m.c.Client.DownloadFile(file, u, param)
+}
+
+// WalkFunc is the type of the function called for each file visited by Walk.
+// Returning filepath.SkipDir from a directory entry skips that directory.
+type WalkFunc func(path string, info types.GuestFileInfo) error
+
+// Walk walks the guest directory tree rooted at dir, calling fn for each file or directory in the tree.
+// Directory contents are listed via ListFiles, paging through the results using the remaining count.
+func (m FileManager) Walk(ctx context.Context, auth types.BaseGuestAuthentication, dir string, fn WalkFunc) error {
+	sep := guestPathSeparator(dir)
+	var offset int32
+
+	for {
+		info, err := m.ListFiles(ctx, auth, dir, offset, 0, "")
+		if err != nil {
+			return err
+		}
+
+		for _, file := range info.Files {
+			if file.Path == "." || file.Path == ".." {
+				continue
+			}
+
+			path := strings.TrimSuffix(dir, sep) + sep + file.Path
+
+			err = fn(path, file)
+			if file.Type == string(types.GuestFileTypeDirectory) {
+				if err == filepath.SkipDir {
+					continue
+				}
+				if err == nil {
+					err = m.Walk(ctx, auth, path, fn)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if info.Remaining == 0 {
+			return nil
+		}
+
+		if len(info.Files) == 0 {
+			return fmt.Errorf("%s: no files listed, %d remaining", dir, info.Remaining)
+		}
+
+		offset += int32(len(info.Files))
+	}
+}
+
+// UploadDirectory recursively transfers the local directory src to the guest directory dst.
+// Directories are created in the guest as needed, existing files are replaced only if overwrite is true.
+// If s is not nil, progress of each file transfer is reported to s, prefixed with the file name.
+func (m FileManager) UploadDirectory(ctx context.Context, auth types.BaseGuestAuthentication, src string, dst string, overwrite bool, s progress.Sinker) error {
+	var agg *progress.Aggregator
+	if s != nil {
+		agg = progress.NewAggregator(s)
+		defer agg.Done()
+	}
+
+	sep := guestPathSeparator(dst)
+
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		target := dst
+		if rel != "." {
+			target = strings.TrimSuffix(dst, sep) + sep + strings.Replace(filepath.ToSlash(rel), "/", sep, -1)
+		}
+
+		if info.IsDir() {
+			err = m.MakeDirectory(ctx, auth, target, true)
+			if soap.IsSoapFault(err) {
+				if _, ok := soap.ToSoapFault(err).VimFault().(types.FileAlreadyExists); ok {
+					return nil
+				}
+			}
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil // symlinks, devices, etc are not transferred
+		}
+
+		p := soap.DefaultUpload
+		p.ContentLength = info.Size()
+		if agg != nil {
+			p.Progress = progress.Prefix(agg, rel)
+		}
+
+		return m.UploadFile(ctx, auth, path, target, nil, overwrite, &p)
+	})
+}
+
+// DownloadDirectory recursively transfers the guest directory src to the local directory dst.
+// Local directories are created as needed, existing files are replaced only if overwrite is true.
+// If s is not nil, progress of each file transfer is reported to s, prefixed with the file name.
+func (m FileManager) DownloadDirectory(ctx context.Context, auth types.BaseGuestAuthentication, src string, dst string, overwrite bool, s progress.Sinker) error {
+	var agg *progress.Aggregator
+	if s != nil {
+		agg = progress.NewAggregator(s)
+		defer agg.Done()
+	}
+
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	sep := guestPathSeparator(src)
+	prefix := strings.TrimSuffix(src, sep) + sep
+
+	return m.Walk(ctx, auth, src, func(path string, info types.GuestFileInfo) error {
+		rel := strings.Replace(strings.TrimPrefix(path, prefix), sep, "/", -1)
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+
+		switch info.Type {
+		case string(types.GuestFileTypeDirectory):
+			return os.MkdirAll(target, 0755)
+		case string(types.GuestFileTypeFile):
+		default:
+			return nil // symlinks are not transferred
+		}
+
+		if _, err := os.Stat(target); err == nil && !overwrite {
+			return fmt.Errorf("%s: %s", target, os.ErrExist)
+		}
+
+		p := soap.DefaultDownload
+		if agg != nil {
+			p.Progress = progress.Prefix(agg, rel)
+		}
+
+		return m.DownloadFile(ctx, auth, path, target, &p)
+	})
+}
+
+// guestPathSeparator returns the path separator used by the given guest path,
+// which is the backslash for Windows guests.
+func guestPathSeparator(path string) string {
+	if strings.Contains(path, `\`) {
+		return `\`
+	}
+	return "/"
+}
@@ -70,3 +70,25 @@ func (m OperationsManager) ProcessManager(ctx context.Context) (*ProcessManager,
 
 	return &ProcessManager{*g.ProcessManager, m.vm, m.c}, nil
 }
+
+func (m OperationsManager) RegistryManager(ctx context.Context) (*RegistryManager, error) {
+	var g mo.GuestOperationsManager
+
+	err := m.retrieveOne(ctx, "guestWindowsRegistryManager", &g)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistryManager{*g.GuestWindowsRegistryManager, m.vm, m.c}, nil
+}
+
+func (m OperationsManager) AliasManager(ctx context.Context) (*AliasManager, error) {
+	var g mo.GuestOperationsManager
+
+	err := m.retrieveOne(ctx, "aliasManager", &g)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AliasManager{*g.AliasManager, m.vm, m.c}, nil
+}
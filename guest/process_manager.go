@@ -17,6 +17,9 @@ limitations under the License.
 package guest
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/RotatingFans/govmomi/vim25"
 	"github.com/RotatingFans/govmomi/vim25/methods"
 	"github.com/RotatingFans/govmomi/vim25/types"
@@ -94,3 +97,35 @@ func (m ProcessManager) TerminateProcess(ctx context.Context, auth types.BaseGue
 	_, err := methods.TerminateProcessInGuest(ctx, m.c, &req)
 	return err
 }
+
+// Wait polls ListProcesses until the process with the given pid has exited, returning its final info,
+// which includes the ExitCode.  The polling interval starts small and backs off to a maximum of 5 seconds.
+func (m ProcessManager) Wait(ctx context.Context, auth types.BaseGuestAuthentication, pid int64) (*types.GuestProcessInfo, error) {
+	delay := 100 * time.Millisecond
+	max := 5 * time.Second
+
+	for {
+		procs, err := m.ListProcesses(ctx, auth, []int64{pid})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(procs) != 1 {
+			return nil, fmt.Errorf("guest process %d not found", pid)
+		}
+
+		if procs[0].EndTime != nil {
+			return &procs[0], nil
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if delay *= 2; delay > max {
+			delay = max
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/property"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// Cmd describes a program to run in the guest, see OperationsManager.Run.
+type Cmd struct {
+	// Path is the absolute path of the program to run.
+	Path string
+	// Args are the program arguments, joined by a space when started.
+	Args []string
+	// Dir is the working directory of the program, the guest default is used if empty.
+	Dir string
+	// Env is the list of environment variables, each in the form key=value.
+	Env []string
+
+	// Stdout and Stderr, if not nil, receive the program's output.
+	// When set, the program is run via the guest shell with its output redirected to
+	// temporary files in the guest, which are downloaded and removed once the program exits.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// ExitError is returned by OperationsManager.Run when the program exits with a non-zero code.
+type ExitError struct {
+	Pid      int64
+	ExitCode int32
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("guest process %d: exit status %d", e.Pid, e.ExitCode)
+}
+
+func (m OperationsManager) guestFamily(ctx context.Context) (string, error) {
+	var vm mo.VirtualMachine
+
+	pc := property.DefaultCollector(m.c)
+	err := pc.RetrieveOne(ctx, m.vm, []string{"guest.guestFamily"}, &vm)
+	if err != nil {
+		return "", err
+	}
+
+	if vm.Guest == nil {
+		return "", nil
+	}
+
+	return vm.Guest.GuestFamily, nil
+}
+
+// shellQuote quotes s for use as a single argument to the guest shell.
+func shellQuote(s string, windows bool) string {
+	if windows {
+		return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// redirect wraps the program spec to run via the guest shell, redirecting output to the given files.
+func redirect(spec *types.GuestProgramSpec, stdout, stderr string, windows bool) {
+	command := shellQuote(spec.ProgramPath, windows)
+	if spec.Arguments != "" {
+		command += " " + spec.Arguments
+	}
+
+	if stdout != "" {
+		command += " >" + shellQuote(stdout, windows)
+	}
+	if stderr != "" {
+		command += " 2>" + shellQuote(stderr, windows)
+	}
+
+	if windows {
+		spec.ProgramPath = `C:\Windows\System32\cmd.exe`
+		spec.Arguments = `/s /c "` + command + `"`
+	} else {
+		spec.ProgramPath = "/bin/sh"
+		spec.Arguments = "-c " + shellQuote(command, false)
+	}
+}
+
+// Run starts the program described by cmd in the guest and waits for it to exit.
+// An *ExitError is returned if the program exits with a non-zero code.
+func (m OperationsManager) Run(ctx context.Context, auth types.BaseGuestAuthentication, cmd *Cmd) error {
+	pm, err := m.ProcessManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec := types.GuestProgramSpec{
+		ProgramPath:      cmd.Path,
+		Arguments:        strings.Join(cmd.Args, " "),
+		WorkingDirectory: cmd.Dir,
+		EnvVariables:     cmd.Env,
+	}
+
+	var fm *FileManager
+	var stdout, stderr string
+
+	if cmd.Stdout != nil || cmd.Stderr != nil {
+		family, err := m.guestFamily(ctx)
+		if err != nil {
+			return err
+		}
+
+		fm, err = m.FileManager(ctx)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Stdout != nil {
+			if stdout, err = fm.CreateTemporaryFile(ctx, auth, "govmomi-", ".stdout"); err != nil {
+				return err
+			}
+			defer fm.DeleteFile(ctx, auth, stdout)
+		}
+
+		if cmd.Stderr != nil {
+			if stderr, err = fm.CreateTemporaryFile(ctx, auth, "govmomi-", ".stderr"); err != nil {
+				return err
+			}
+			defer fm.DeleteFile(ctx, auth, stderr)
+		}
+
+		redirect(&spec, stdout, stderr, family == string(types.VirtualMachineGuestOsFamilyWindowsGuest))
+	}
+
+	pid, err := pm.StartProgram(ctx, auth, &spec)
+	if err != nil {
+		return err
+	}
+
+	info, err := pm.Wait(ctx, auth, pid)
+	if err != nil {
+		return err
+	}
+
+	output := []struct {
+		path string
+		w    io.Writer
+	}{
+		{stdout, cmd.Stdout},
+		{stderr, cmd.Stderr},
+	}
+
+	for _, o := range output {
+		if o.path == "" {
+			continue
+		}
+
+		if err = m.copyOutput(ctx, fm, auth, o.path, o.w); err != nil {
+			return err
+		}
+	}
+
+	if info.ExitCode != 0 {
+		return &ExitError{Pid: pid, ExitCode: info.ExitCode}
+	}
+
+	return nil
+}
+
+func (m OperationsManager) copyOutput(ctx context.Context, fm *FileManager, auth types.BaseGuestAuthentication, path string, w io.Writer) error {
+	r, _, err := fm.Download(ctx, auth, path, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	_, err = io.Copy(w, r)
+	return err
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"testing"
+
+	"github.com/RotatingFans/govmomi/vim25/types"
+)
+
+func TestRedirect(t *testing.T) {
+	tests := []struct {
+		windows bool
+		path    string
+		args    string
+		expect  types.GuestProgramSpec
+	}{
+		{
+			false, "/bin/echo", "hello",
+			types.GuestProgramSpec{
+				ProgramPath: "/bin/sh",
+				Arguments:   `-c ''\''/bin/echo'\'' hello >'\''/tmp/out'\'' 2>'\''/tmp/err'\'''`,
+			},
+		},
+		{
+			true, `C:\Program Files\app.exe`, "/v",
+			types.GuestProgramSpec{
+				ProgramPath: `C:\Windows\System32\cmd.exe`,
+				Arguments:   `/s /c ""C:\Program Files\app.exe" /v >"/tmp/out" 2>"/tmp/err""`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		spec := types.GuestProgramSpec{
+			ProgramPath: test.path,
+			Arguments:   test.args,
+		}
+
+		redirect(&spec, "/tmp/out", "/tmp/err", test.windows)
+
+		if spec.ProgramPath != test.expect.ProgramPath {
+			t.Errorf("expected %s, got %s", test.expect.ProgramPath, spec.ProgramPath)
+		}
+
+		if spec.Arguments != test.expect.Arguments {
+			t.Errorf("expected %s, got %s", test.expect.Arguments, spec.Arguments)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// RegistryManager wraps the GuestWindowsRegistryManager, which manages the registry of a Windows guest.
+type RegistryManager struct {
+	types.ManagedObjectReference
+
+	vm types.ManagedObjectReference
+
+	c *vim25.Client
+}
+
+func (m RegistryManager) Reference() types.ManagedObjectReference {
+	return m.ManagedObjectReference
+}
+
+func (m RegistryManager) CreateRegistryKey(ctx context.Context, auth types.BaseGuestAuthentication, keyName types.GuestRegKeyNameSpec, isVolatile bool, classType string) error {
+	req := types.CreateRegistryKeyInGuest{
+		This:       m.Reference(),
+		Vm:         m.vm,
+		Auth:       auth,
+		KeyName:    keyName,
+		IsVolatile: isVolatile,
+		ClassType:  classType,
+	}
+
+	_, err := methods.CreateRegistryKeyInGuest(ctx, m.c, &req)
+	return err
+}
+
+func (m RegistryManager) ListRegistryKeys(ctx context.Context, auth types.BaseGuestAuthentication, keyName types.GuestRegKeyNameSpec, recursive bool, matchPattern string) ([]types.GuestRegKeyRecordSpec, error) {
+	req := types.ListRegistryKeysInGuest{
+		This:         m.Reference(),
+		Vm:           m.vm,
+		Auth:         auth,
+		KeyName:      keyName,
+		Recursive:    recursive,
+		MatchPattern: matchPattern,
+	}
+
+	res, err := methods.ListRegistryKeysInGuest(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+func (m RegistryManager) DeleteRegistryKey(ctx context.Context, auth types.BaseGuestAuthentication, keyName types.GuestRegKeyNameSpec, recursive bool) error {
+	req := types.DeleteRegistryKeyInGuest{
+		This:      m.Reference(),
+		Vm:        m.vm,
+		Auth:      auth,
+		KeyName:   keyName,
+		Recursive: recursive,
+	}
+
+	_, err := methods.DeleteRegistryKeyInGuest(ctx, m.c, &req)
+	return err
+}
+
+func (m RegistryManager) ListRegistryValues(ctx context.Context, auth types.BaseGuestAuthentication, keyName types.GuestRegKeyNameSpec, expandStrings bool, matchPattern string) ([]types.GuestRegValueSpec, error) {
+	req := types.ListRegistryValuesInGuest{
+		This:          m.Reference(),
+		Vm:            m.vm,
+		Auth:          auth,
+		KeyName:       keyName,
+		ExpandStrings: expandStrings,
+		MatchPattern:  matchPattern,
+	}
+
+	res, err := methods.ListRegistryValuesInGuest(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+func (m RegistryManager) SetRegistryValue(ctx context.Context, auth types.BaseGuestAuthentication, value types.GuestRegValueSpec) error {
+	req := types.SetRegistryValueInGuest{
+		This:  m.Reference(),
+		Vm:    m.vm,
+		Auth:  auth,
+		Value: value,
+	}
+
+	_, err := methods.SetRegistryValueInGuest(ctx, m.c, &req)
+	return err
+}
+
+func (m RegistryManager) DeleteRegistryValue(ctx context.Context, auth types.BaseGuestAuthentication, valueName types.GuestRegValueNameSpec) error {
+	req := types.DeleteRegistryValueInGuest{
+		This:      m.Reference(),
+		Vm:        m.vm,
+		Auth:      auth,
+		ValueName: valueName,
+	}
+
+	_, err := methods.DeleteRegistryValueInGuest(ctx, m.c, &req)
+	return err
+}
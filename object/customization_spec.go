@@ -0,0 +1,231 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/RotatingFans/govmomi/vim25/types"
+)
+
+// CustomizationNIC describes the IP settings of a network adapter for CustomizationSpecBuilder.
+type CustomizationNIC struct {
+	// IP is the static IPv4 address of the adapter, DHCP is used if empty.
+	IP      string
+	Netmask string
+	Gateway []string
+	// DNS is the list of DNS servers for the adapter, only used by Windows guests.
+	DNS []string
+}
+
+// CustomizationSpecBuilder builds a types.CustomizationSpec for a Linux (CustomizationLinuxPrep)
+// or Windows (CustomizationSysprep) guest.
+type CustomizationSpecBuilder struct {
+	Windows bool
+
+	// Hostname of the guest, the VM name is used if empty.
+	Hostname string
+	Domain   string
+	// DNS servers and search suffixes, applied to all adapters.
+	DNS       []string
+	DNSSuffix []string
+	// TimeZone is an Olson name such as "America/New_York" for Linux,
+	// or a Microsoft time zone index such as "035" for Windows.
+	TimeZone string
+
+	// NICs are the adapter settings, in the order of the VM's network adapters.
+	// If empty, DHCP is used for all adapters.
+	NICs []CustomizationNIC
+
+	// Windows only settings.  FullName and OrgName default to "Administrator" and "Organization".
+	FullName            string
+	OrgName             string
+	ProductKey          string
+	AdminPassword       string
+	JoinDomain          string
+	DomainAdmin         string
+	DomainAdminPassword string
+}
+
+func (b *CustomizationSpecBuilder) identity() (types.BaseCustomizationIdentitySettings, error) {
+	var name types.BaseCustomizationName = &types.CustomizationVirtualMachineName{}
+	if b.Hostname != "" {
+		name = &types.CustomizationFixedName{Name: b.Hostname}
+	}
+
+	if !b.Windows {
+		return &types.CustomizationLinuxPrep{
+			HostName:   name,
+			Domain:     b.Domain,
+			TimeZone:   b.TimeZone,
+			HwClockUTC: types.NewBool(true),
+		}, nil
+	}
+
+	sysprep := &types.CustomizationSysprep{
+		GuiUnattended: types.CustomizationGuiUnattended{
+			TimeZone: 85, // (GMT) Greenwich Mean Time
+		},
+		UserData: types.CustomizationUserData{
+			FullName:     b.FullName,
+			OrgName:      b.OrgName,
+			ComputerName: name,
+			ProductId:    b.ProductKey,
+		},
+		Identification: types.CustomizationIdentification{
+			JoinWorkgroup: "WORKGROUP",
+		},
+	}
+
+	if sysprep.UserData.FullName == "" {
+		sysprep.UserData.FullName = "Administrator"
+	}
+
+	if sysprep.UserData.OrgName == "" {
+		sysprep.UserData.OrgName = "Organization"
+	}
+
+	if b.TimeZone != "" {
+		tz, err := strconv.ParseInt(b.TimeZone, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Windows time zone index: %s", b.TimeZone)
+		}
+		sysprep.GuiUnattended.TimeZone = int32(tz)
+	}
+
+	if b.AdminPassword != "" {
+		sysprep.GuiUnattended.Password = &types.CustomizationPassword{
+			Value:     b.AdminPassword,
+			PlainText: true,
+		}
+	}
+
+	if b.JoinDomain != "" {
+		if b.DomainAdmin == "" || b.DomainAdminPassword == "" {
+			return nil, errors.New("domain admin and password are required to join a domain")
+		}
+
+		sysprep.Identification = types.CustomizationIdentification{
+			JoinDomain:  b.JoinDomain,
+			DomainAdmin: b.DomainAdmin,
+			DomainAdminPassword: &types.CustomizationPassword{
+				Value:     b.DomainAdminPassword,
+				PlainText: true,
+			},
+		}
+	}
+
+	return sysprep, nil
+}
+
+func (b *CustomizationSpecBuilder) adapter(nic CustomizationNIC) types.CustomizationAdapterMapping {
+	var m types.CustomizationAdapterMapping
+
+	if nic.IP == "" {
+		m.Adapter.Ip = &types.CustomizationDhcpIpGenerator{}
+	} else {
+		m.Adapter.Ip = &types.CustomizationFixedIp{IpAddress: nic.IP}
+		m.Adapter.SubnetMask = nic.Netmask
+		m.Adapter.Gateway = nic.Gateway
+	}
+
+	if b.Windows {
+		m.Adapter.DnsServerList = nic.DNS
+		m.Adapter.DnsDomain = b.Domain
+	}
+
+	return m
+}
+
+// Spec returns the customization spec.  If devices is not nil, it must be the device list of the VM
+// to customize, and the number of NICs is validated against its network adapters.
+func (b *CustomizationSpecBuilder) Spec(devices VirtualDeviceList) (*types.CustomizationSpec, error) {
+	nics := b.NICs
+
+	if devices != nil {
+		cards := devices.Select(func(device types.BaseVirtualDevice) bool {
+			_, ok := device.(types.BaseVirtualEthernetCard)
+			return ok
+		})
+
+		switch {
+		case len(nics) == 0:
+			nics = make([]CustomizationNIC, len(cards))
+		case len(nics) != len(cards):
+			return nil, fmt.Errorf("%d NIC settings given, but the VM has %d network adapters", len(nics), len(cards))
+		}
+	}
+
+	for i, nic := range nics {
+		if nic.IP != "" && nic.Netmask == "" {
+			return nil, fmt.Errorf("NIC %d: netmask is required with a static IP", i)
+		}
+	}
+
+	identity, err := b.identity()
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &types.CustomizationSpec{
+		Identity: identity,
+		GlobalIPSettings: types.CustomizationGlobalIPSettings{
+			DnsSuffixList: b.DNSSuffix,
+			DnsServerList: b.DNS,
+		},
+	}
+
+	if b.Windows {
+		spec.Options = &types.CustomizationWinOptions{
+			ChangeSID: true,
+			Reboot:    types.CustomizationSysprepRebootOptionReboot,
+		}
+	} else {
+		spec.Options = &types.CustomizationLinuxOptions{}
+	}
+
+	for _, nic := range nics {
+		spec.NicSettingMap = append(spec.NicSettingMap, b.adapter(nic))
+	}
+
+	return spec, nil
+}
+
+// SpecItem returns the customization spec wrapped in a types.CustomizationSpecItem with the given name,
+// as used by CustomizationSpecManager.
+func (b *CustomizationSpecBuilder) SpecItem(name string, description string, devices VirtualDeviceList) (*types.CustomizationSpecItem, error) {
+	spec, err := b.Spec(devices)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := "Linux"
+	if b.Windows {
+		kind = "Windows"
+	}
+
+	return &types.CustomizationSpecItem{
+		Info: types.CustomizationSpecInfo{
+			Name:        name,
+			Description: description,
+			Type:        kind,
+		},
+		Spec: *spec,
+	}, nil
+}
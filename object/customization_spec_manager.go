@@ -19,6 +19,7 @@ package object
 import (
 	"github.com/RotatingFans/govmomi/vim25"
 	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
 	"github.com/RotatingFans/govmomi/vim25/types"
 	"golang.org/x/net/context"
 )
@@ -163,3 +164,15 @@ func (cs CustomizationSpecManager) XmlToCustomizationSpecItem(ctx context.Contex
 	}
 	return &res.Returnval, nil
 }
+
+// Info returns the info of all customization specs.
+func (cs CustomizationSpecManager) Info(ctx context.Context) ([]types.CustomizationSpecInfo, error) {
+	var m mo.CustomizationSpecManager
+
+	err := cs.Properties(ctx, cs.Reference(), []string{"info"}, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Info, nil
+}
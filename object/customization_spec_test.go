@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/RotatingFans/govmomi/vim25/types"
+)
+
+func TestCustomizationSpecBuilder(t *testing.T) {
+	devices := VirtualDeviceList{
+		&types.VirtualVmxnet3{},
+		&types.VirtualE1000{},
+		&types.VirtualDisk{},
+	}
+
+	b := CustomizationSpecBuilder{
+		NICs: []CustomizationNIC{{IP: "10.0.0.10", Netmask: "255.255.255.0"}},
+	}
+
+	if _, err := b.Spec(devices); err == nil {
+		t.Error("expected NIC count error")
+	}
+
+	b.NICs = nil
+
+	spec, err := b.Spec(devices)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(spec.NicSettingMap) != 2 {
+		t.Errorf("NicSettingMap=%d", len(spec.NicSettingMap))
+	}
+
+	if _, ok := spec.Identity.(*types.CustomizationLinuxPrep); !ok {
+		t.Errorf("Identity=%T", spec.Identity)
+	}
+
+	b.Windows = true
+	b.TimeZone = "bogus"
+
+	if _, err = b.Spec(nil); err == nil {
+		t.Error("expected time zone error")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"errors"
+
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostAuthenticationManager struct {
+	Common
+}
+
+func NewHostAuthenticationManager(c *vim25.Client, ref types.ManagedObjectReference) *HostAuthenticationManager {
+	return &HostAuthenticationManager{
+		Common: NewCommon(c, ref),
+	}
+}
+
+func (m HostAuthenticationManager) Info(ctx context.Context) (*types.HostAuthenticationManagerInfo, error) {
+	var ham mo.HostAuthenticationManager
+
+	err := m.Properties(ctx, m.Reference(), []string{"info"}, &ham)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ham.Info, nil
+}
+
+// SupportedStore returns the authentication stores supported by the host.
+func (m HostAuthenticationManager) SupportedStore(ctx context.Context) ([]types.ManagedObjectReference, error) {
+	var ham mo.HostAuthenticationManager
+
+	err := m.Properties(ctx, m.Reference(), []string{"supportedStore"}, &ham)
+	if err != nil {
+		return nil, err
+	}
+
+	return ham.SupportedStore, nil
+}
+
+// ActiveDirectory returns the Active Directory authentication store of the host.
+func (m HostAuthenticationManager) ActiveDirectory(ctx context.Context) (*HostActiveDirectoryAuthentication, error) {
+	stores, err := m.SupportedStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range stores {
+		if ref.Type == "HostActiveDirectoryAuthentication" {
+			return NewHostActiveDirectoryAuthentication(m.c, ref), nil
+		}
+	}
+
+	return nil, errors.New("active directory authentication is not supported")
+}
+
+type HostActiveDirectoryAuthentication struct {
+	Common
+}
+
+func NewHostActiveDirectoryAuthentication(c *vim25.Client, ref types.ManagedObjectReference) *HostActiveDirectoryAuthentication {
+	return &HostActiveDirectoryAuthentication{
+		Common: NewCommon(c, ref),
+	}
+}
+
+func (a HostActiveDirectoryAuthentication) Info(ctx context.Context) (*types.HostActiveDirectoryInfo, error) {
+	var ad mo.HostActiveDirectoryAuthentication
+
+	err := a.Properties(ctx, a.Reference(), []string{"info"}, &ad)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := ad.Info.(*types.HostActiveDirectoryInfo)
+	if !ok {
+		return nil, errors.New("unexpected active directory info type")
+	}
+
+	return info, nil
+}
+
+func (a HostActiveDirectoryAuthentication) JoinDomain(ctx context.Context, domain, user, password string) (*Task, error) {
+	req := types.JoinDomain_Task{
+		This:       a.Reference(),
+		DomainName: domain,
+		UserName:   user,
+		Password:   password,
+	}
+
+	res, err := methods.JoinDomain_Task(ctx, a.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(a.c, res.Returnval), nil
+}
+
+// JoinDomainWithCAM joins the domain using the vSphere Authentication Proxy (CAM) server,
+// so that AD credentials are not needed.
+func (a HostActiveDirectoryAuthentication) JoinDomainWithCAM(ctx context.Context, domain, server string) (*Task, error) {
+	req := types.JoinDomainWithCAM_Task{
+		This:       a.Reference(),
+		DomainName: domain,
+		CamServer:  server,
+	}
+
+	res, err := methods.JoinDomainWithCAM_Task(ctx, a.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(a.c, res.Returnval), nil
+}
+
+// LeaveCurrentDomain leaves the current domain. If force is true, the domain is left
+// even if there are permissions assigned to AD users or groups.
+func (a HostActiveDirectoryAuthentication) LeaveCurrentDomain(ctx context.Context, force bool) (*Task, error) {
+	req := types.LeaveCurrentDomain_Task{
+		This:  a.Reference(),
+		Force: force,
+	}
+
+	res, err := methods.LeaveCurrentDomain_Task(ctx, a.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(a.c, res.Returnval), nil
+}
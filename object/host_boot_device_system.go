@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostBootDeviceSystem struct {
+	Common
+}
+
+func NewHostBootDeviceSystem(c *vim25.Client, ref types.ManagedObjectReference) *HostBootDeviceSystem {
+	return &HostBootDeviceSystem{
+		Common: NewCommon(c, ref),
+	}
+}
+
+// QueryBootDevices returns the available boot devices and the key of the device the host will boot from next.
+func (s HostBootDeviceSystem) QueryBootDevices(ctx context.Context) (*types.HostBootDeviceInfo, error) {
+	req := types.QueryBootDevices{
+		This: s.Reference(),
+	}
+
+	res, err := methods.QueryBootDevices(ctx, s.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Returnval == nil {
+		return new(types.HostBootDeviceInfo), nil
+	}
+
+	return res.Returnval, nil
+}
+
+// UpdateBootDevice sets the device the host will boot from next, by key.
+func (s HostBootDeviceSystem) UpdateBootDevice(ctx context.Context, key string) error {
+	req := types.UpdateBootDevice{
+		This: s.Reference(),
+		Key:  key,
+	}
+
+	_, err := methods.UpdateBootDevice(ctx, s.c, &req)
+	return err
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package object
 
 import (
+	"errors"
+
 	"github.com/RotatingFans/govmomi/vim25"
 	"github.com/RotatingFans/govmomi/vim25/mo"
 	"github.com/RotatingFans/govmomi/vim25/types"
@@ -110,6 +112,21 @@ func (m HostConfigManager) AccountManager(ctx context.Context) (*HostAccountMana
 	return NewHostAccountManager(m.c, *h.ConfigManager.AccountManager), nil
 }
 
+func (m HostConfigManager) AuthenticationManager(ctx context.Context) (*HostAuthenticationManager, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.authenticationManager"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ConfigManager.AuthenticationManager == nil {
+		return nil, errors.New("host authentication manager is not supported")
+	}
+
+	return NewHostAuthenticationManager(m.c, *h.ConfigManager.AuthenticationManager), nil
+}
+
 func (m HostConfigManager) OptionManager(ctx context.Context) (*OptionManager, error) {
 	var h mo.HostSystem
 
@@ -131,3 +148,136 @@ func (m HostConfigManager) ServiceSystem(ctx context.Context) (*HostServiceSyste
 
 	return NewHostServiceSystem(m.c, *h.ConfigManager.ServiceSystem), nil
 }
+
+func (m HostConfigManager) DateTimeSystem(ctx context.Context) (*HostDateTimeSystem, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.dateTimeSystem"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHostDateTimeSystem(m.c, *h.ConfigManager.DateTimeSystem), nil
+}
+
+func (m HostConfigManager) CertificateManager(ctx context.Context) (*HostCertificateManager, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.certificateManager"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Added in 6.0
+	if h.ConfigManager.CertificateManager == nil {
+		return nil, errors.New("host certificate manager is not supported")
+	}
+
+	return NewHostCertificateManager(m.c, *h.ConfigManager.CertificateManager), nil
+}
+
+func (m HostConfigManager) IscsiManager(ctx context.Context) (*IscsiManager, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.iscsiManager"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Added in 5.0
+	if h.ConfigManager.IscsiManager == nil {
+		return nil, errors.New("iSCSI manager is not supported")
+	}
+
+	return NewIscsiManager(m.c, *h.ConfigManager.IscsiManager), nil
+}
+
+func (m HostConfigManager) FirmwareSystem(ctx context.Context) (*HostFirmwareSystem, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.firmwareSystem"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ConfigManager.FirmwareSystem == nil {
+		return nil, errors.New("host firmware system is not supported")
+	}
+
+	return NewHostFirmwareSystem(m.c, *h.ConfigManager.FirmwareSystem, m.Reference()), nil
+}
+
+func (m HostConfigManager) PowerSystem(ctx context.Context) (*HostPowerSystem, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.powerSystem"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ConfigManager.PowerSystem == nil {
+		return nil, errors.New("host power system is not supported")
+	}
+
+	return NewHostPowerSystem(m.c, *h.ConfigManager.PowerSystem), nil
+}
+
+func (m HostConfigManager) SnmpSystem(ctx context.Context) (*HostSnmpSystem, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.snmpSystem"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ConfigManager.SnmpSystem == nil {
+		return nil, errors.New("host SNMP system is not supported")
+	}
+
+	return NewHostSnmpSystem(m.c, *h.ConfigManager.SnmpSystem), nil
+}
+
+func (m HostConfigManager) KernelModuleSystem(ctx context.Context) (*HostKernelModuleSystem, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.kernelModuleSystem"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ConfigManager.KernelModuleSystem == nil {
+		return nil, errors.New("host kernel module system is not supported")
+	}
+
+	return NewHostKernelModuleSystem(m.c, *h.ConfigManager.KernelModuleSystem), nil
+}
+
+func (m HostConfigManager) BootDeviceSystem(ctx context.Context) (*HostBootDeviceSystem, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.bootDeviceSystem"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ConfigManager.BootDeviceSystem == nil {
+		return nil, errors.New("host boot device system is not supported")
+	}
+
+	return NewHostBootDeviceSystem(m.c, *h.ConfigManager.BootDeviceSystem), nil
+}
+
+func (m HostConfigManager) HealthStatusSystem(ctx context.Context) (*HostHealthStatusSystem, error) {
+	var h mo.HostSystem
+
+	err := m.Properties(ctx, m.Reference(), []string{"configManager.healthStatusSystem"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ConfigManager.HealthStatusSystem == nil {
+		return nil, errors.New("host health status system is not supported")
+	}
+
+	return NewHostHealthStatusSystem(m.c, *h.ConfigManager.HealthStatusSystem, m.Reference()), nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"time"
+
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostDateTimeSystem struct {
+	Common
+}
+
+func NewHostDateTimeSystem(c *vim25.Client, ref types.ManagedObjectReference) *HostDateTimeSystem {
+	return &HostDateTimeSystem{
+		Common: NewCommon(c, ref),
+	}
+}
+
+// Info returns the time zone and NTP configuration of the host.
+func (s HostDateTimeSystem) Info(ctx context.Context) (*types.HostDateTimeInfo, error) {
+	var hs mo.HostDateTimeSystem
+
+	err := s.Properties(ctx, s.Reference(), []string{"dateTimeInfo"}, &hs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hs.DateTimeInfo, nil
+}
+
+// Query returns the current date and time of the host.
+func (s HostDateTimeSystem) Query(ctx context.Context) (*time.Time, error) {
+	req := types.QueryDateTime{
+		This: s.Reference(),
+	}
+
+	res, err := methods.QueryDateTime(ctx, s.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Returnval, nil
+}
+
+// Skew returns the difference between the host clock and the client clock,
+// a positive value means the host clock is ahead.  The client time is taken
+// as the midpoint of the QueryDateTime round trip.
+func (s HostDateTimeSystem) Skew(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+
+	now, err := s.Query(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rtt := time.Since(start)
+	client := start.Add(rtt / 2)
+
+	return now.Sub(client), nil
+}
+
+// Update sets the date and time of the host.
+func (s HostDateTimeSystem) Update(ctx context.Context, date time.Time) error {
+	req := types.UpdateDateTime{
+		This:     s.Reference(),
+		DateTime: date,
+	}
+
+	_, err := methods.UpdateDateTime(ctx, s.c, &req)
+	return err
+}
+
+// UpdateConfig updates the time zone and NTP configuration of the host.
+func (s HostDateTimeSystem) UpdateConfig(ctx context.Context, config types.HostDateTimeConfig) error {
+	req := types.UpdateDateTimeConfig{
+		This:   s.Reference(),
+		Config: config,
+	}
+
+	_, err := methods.UpdateDateTimeConfig(ctx, s.c, &req)
+	return err
+}
+
+// TimeZones returns the time zones available on the host.
+func (s HostDateTimeSystem) TimeZones(ctx context.Context) ([]types.HostDateTimeSystemTimeZone, error) {
+	req := types.QueryAvailableTimeZones{
+		This: s.Reference(),
+	}
+
+	res, err := methods.QueryAvailableTimeZones(ctx, s.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/session"
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/soap"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostFirmwareSystem struct {
+	Common
+	Host *HostSystem
+}
+
+func NewHostFirmwareSystem(c *vim25.Client, ref types.ManagedObjectReference, host types.ManagedObjectReference) *HostFirmwareSystem {
+	return &HostFirmwareSystem{
+		Common: NewCommon(c, ref),
+		Host:   NewHostSystem(c, host),
+	}
+}
+
+// BackupFirmwareConfiguration generates a configuration bundle and returns the URL it can be downloaded from.
+func (s HostFirmwareSystem) BackupFirmwareConfiguration(ctx context.Context) (string, error) {
+	req := types.BackupFirmwareConfiguration{
+		This: s.Reference(),
+	}
+
+	res, err := methods.BackupFirmwareConfiguration(ctx, s.c, &req)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Returnval, nil
+}
+
+// QueryFirmwareConfigUploadURL returns the URL a configuration bundle should be uploaded to
+// before calling RestoreFirmwareConfiguration.
+func (s HostFirmwareSystem) QueryFirmwareConfigUploadURL(ctx context.Context) (string, error) {
+	req := types.QueryFirmwareConfigUploadURL{
+		This: s.Reference(),
+	}
+
+	res, err := methods.QueryFirmwareConfigUploadURL(ctx, s.c, &req)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Returnval, nil
+}
+
+// RestoreFirmwareConfiguration restores the uploaded configuration bundle and reboots the host.
+// If force is true, the bundle is applied even if it was created with a different ESX build.
+func (s HostFirmwareSystem) RestoreFirmwareConfiguration(ctx context.Context, force bool) error {
+	req := types.RestoreFirmwareConfiguration{
+		This:  s.Reference(),
+		Force: force,
+	}
+
+	_, err := methods.RestoreFirmwareConfiguration(ctx, s.c, &req)
+	return err
+}
+
+// ResetFirmwareToFactoryDefaults resets the host configuration to factory defaults and reboots the host.
+func (s HostFirmwareSystem) ResetFirmwareToFactoryDefaults(ctx context.Context) error {
+	req := types.ResetFirmwareToFactoryDefaults{
+		This: s.Reference(),
+	}
+
+	_, err := methods.ResetFirmwareToFactoryDefaults(ctx, s.c, &req)
+	return err
+}
+
+// transferURL parses a URL returned by BackupFirmwareConfiguration or QueryFirmwareConfigUploadURL.
+// The '*' host placeholder refers to the ESX host, when connected to VC an http service ticket
+// is acquired for the host.
+func (s HostFirmwareSystem) transferURL(ctx context.Context, rawurl string, method string) (*url.URL, *http.Cookie, error) {
+	u, err := s.c.Client.ParseURL(rawurl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !s.c.IsVC() {
+		return u, nil, nil
+	}
+
+	name, err := s.Host.ObjectName(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.Host = name
+
+	spec := types.SessionManagerHttpServiceRequestSpec{
+		Url: u.String(),
+		// See SessionManagerHttpServiceRequestSpecMethod enum
+		Method: fmt.Sprintf("http%s%s", method[0:1], strings.ToLower(method[1:])),
+	}
+
+	ticket, err := session.NewManager(s.c).AcquireGenericServiceTicket(ctx, &spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cookie := &http.Cookie{
+		Name:  "vmware_cgi_ticket",
+		Value: ticket.Id,
+	}
+
+	return u, cookie, nil
+}
+
+// Backup generates a configuration bundle via BackupFirmwareConfiguration and downloads it via soap.Download.
+func (s HostFirmwareSystem) Backup(ctx context.Context, param *soap.Download) (io.ReadCloser, int64, error) {
+	p := soap.DefaultDownload
+	if param != nil {
+		p = *param // copy
+	}
+
+	rawurl, err := s.BackupFirmwareConfiguration(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	u, ticket, err := s.transferURL(ctx, rawurl, p.Method)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p.Ticket = ticket
+
+	return s.c.Client.Download(u, &p)
+}
+
+// Restore uploads the configuration bundle f via soap.Upload and applies it via RestoreFirmwareConfiguration.
+// The host must be in maintenance mode and will reboot once the bundle is applied.
+// The size of f is taken from param.ContentLength, or from f itself if it is an *os.File.
+func (s HostFirmwareSystem) Restore(ctx context.Context, f io.Reader, force bool, param *soap.Upload) error {
+	p := soap.DefaultUpload
+	if param != nil {
+		p = *param // Copy since we set ContentLength
+	}
+
+	if file, ok := f.(*os.File); ok && p.ContentLength == 0 {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		p.ContentLength = info.Size()
+	}
+
+	var h mo.HostSystem
+
+	err := s.Host.Properties(ctx, s.Host.Reference(), []string{"runtime.inMaintenanceMode"}, &h)
+	if err != nil {
+		return err
+	}
+
+	if !h.Runtime.InMaintenanceMode {
+		return errors.New("host must be in maintenance mode to restore its configuration")
+	}
+
+	rawurl, err := s.QueryFirmwareConfigUploadURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	u, ticket, err := s.transferURL(ctx, rawurl, p.Method)
+	if err != nil {
+		return err
+	}
+
+	p.Ticket = ticket
+
+	if err = s.c.Client.Upload(f, u, &p); err != nil {
+		return err
+	}
+
+	return s.RestoreFirmwareConfiguration(ctx, force)
+}
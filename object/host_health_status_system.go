@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostHealthStatusSystem struct {
+	Common
+	Host *HostSystem
+}
+
+func NewHostHealthStatusSystem(c *vim25.Client, ref types.ManagedObjectReference, host types.ManagedObjectReference) *HostHealthStatusSystem {
+	return &HostHealthStatusSystem{
+		Common: NewCommon(c, ref),
+		Host:   NewHostSystem(c, host),
+	}
+}
+
+// Runtime returns the host's runtime.healthSystemRuntime property, containing numeric sensor readings
+// and the status of memory, cpu and storage hardware elements.
+// An empty HealthSystemRuntime is returned if the host does not report health status.
+func (s HostHealthStatusSystem) Runtime(ctx context.Context) (*types.HealthSystemRuntime, error) {
+	var h mo.HostSystem
+
+	err := s.Host.Properties(ctx, s.Host.Reference(), []string{"runtime.healthSystemRuntime"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Runtime.HealthSystemRuntime == nil {
+		return new(types.HealthSystemRuntime), nil
+	}
+
+	return h.Runtime.HealthSystemRuntime, nil
+}
+
+// RefreshHealthStatusSystem updates the health status readings.
+func (s HostHealthStatusSystem) RefreshHealthStatusSystem(ctx context.Context) error {
+	req := types.RefreshHealthStatusSystem{
+		This: s.Reference(),
+	}
+
+	_, err := methods.RefreshHealthStatusSystem(ctx, s.c, &req)
+	return err
+}
+
+// ResetSystemHealthInfo resets the state of the system health sensors, such as cleared alarms.
+func (s HostHealthStatusSystem) ResetSystemHealthInfo(ctx context.Context) error {
+	req := types.ResetSystemHealthInfo{
+		This: s.Reference(),
+	}
+
+	_, err := methods.ResetSystemHealthInfo(ctx, s.c, &req)
+	return err
+}
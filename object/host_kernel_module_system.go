@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostKernelModuleSystem struct {
+	Common
+}
+
+func NewHostKernelModuleSystem(c *vim25.Client, ref types.ManagedObjectReference) *HostKernelModuleSystem {
+	return &HostKernelModuleSystem{
+		Common: NewCommon(c, ref),
+	}
+}
+
+func (s HostKernelModuleSystem) QueryModules(ctx context.Context) ([]types.KernelModuleInfo, error) {
+	req := types.QueryModules{
+		This: s.Reference(),
+	}
+
+	res, err := methods.QueryModules(ctx, s.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+// QueryConfiguredModuleOptionString returns the option string the module will be loaded with,
+// which can differ from KernelModuleInfo.OptionString until the module is reloaded.
+func (s HostKernelModuleSystem) QueryConfiguredModuleOptionString(ctx context.Context, name string) (string, error) {
+	req := types.QueryConfiguredModuleOptionString{
+		This: s.Reference(),
+		Name: name,
+	}
+
+	res, err := methods.QueryConfiguredModuleOptionString(ctx, s.c, &req)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Returnval, nil
+}
+
+// UpdateModuleOptionString sets the option string used the next time the module is loaded.
+func (s HostKernelModuleSystem) UpdateModuleOptionString(ctx context.Context, name string, options string) error {
+	req := types.UpdateModuleOptionString{
+		This:    s.Reference(),
+		Name:    name,
+		Options: options,
+	}
+
+	_, err := methods.UpdateModuleOptionString(ctx, s.c, &req)
+	return err
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostPowerSystem struct {
+	Common
+}
+
+func NewHostPowerSystem(c *vim25.Client, ref types.ManagedObjectReference) *HostPowerSystem {
+	return &HostPowerSystem{
+		Common: NewCommon(c, ref),
+	}
+}
+
+// Policies returns the available power policies and the current policy.
+func (s HostPowerSystem) Policies(ctx context.Context) ([]types.HostPowerPolicy, *types.HostPowerPolicy, error) {
+	var ps mo.HostPowerSystem
+
+	err := s.Properties(ctx, s.Reference(), []string{"capability", "info"}, &ps)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ps.Capability.AvailablePolicy, &ps.Info.CurrentPolicy, nil
+}
+
+// ConfigurePowerPolicy sets the current power policy to the policy with the given key.
+func (s HostPowerSystem) ConfigurePowerPolicy(ctx context.Context, key int32) error {
+	req := types.ConfigurePowerPolicy{
+		This: s.Reference(),
+		Key:  key,
+	}
+
+	_, err := methods.ConfigurePowerPolicy(ctx, s.c, &req)
+	return err
+}
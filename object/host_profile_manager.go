@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostProfileManager struct {
+	Common
+}
+
+// GetHostProfileManager wraps NewHostProfileManager, returning ErrNotSupported
+// when the client is not connected to a vCenter instance.
+func GetHostProfileManager(c *vim25.Client) (*HostProfileManager, error) {
+	if c.ServiceContent.HostProfileManager == nil {
+		return nil, ErrNotSupported
+	}
+	return NewHostProfileManager(c), nil
+}
+
+func NewHostProfileManager(c *vim25.Client) *HostProfileManager {
+	m := HostProfileManager{
+		Common: NewCommon(c, *c.ServiceContent.HostProfileManager),
+	}
+
+	return &m
+}
+
+// Profiles returns all host profiles.
+func (m HostProfileManager) Profiles(ctx context.Context) ([]*HostProfile, error) {
+	var pm mo.HostProfileManager
+
+	err := m.Properties(ctx, m.Reference(), []string{"profile"}, &pm)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []*HostProfile
+
+	for _, ref := range pm.Profile {
+		profiles = append(profiles, NewHostProfile(m.c, ref))
+	}
+
+	return profiles, nil
+}
+
+func (m HostProfileManager) CreateProfile(ctx context.Context, spec types.BaseProfileCreateSpec) (*HostProfile, error) {
+	req := types.CreateProfile{
+		This:       m.Reference(),
+		CreateSpec: spec,
+	}
+
+	res, err := methods.CreateProfile(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHostProfile(m.c, res.Returnval), nil
+}
+
+// CreateFromHost creates a host profile named name, extracted from the given reference host.
+func (m HostProfileManager) CreateFromHost(ctx context.Context, name string, annotation string, host *HostSystem) (*HostProfile, error) {
+	spec := types.HostProfileHostBasedConfigSpec{
+		Host: host.Reference(),
+	}
+
+	spec.Name = name
+	spec.Annotation = annotation
+	spec.Enabled = types.NewBool(true)
+
+	return m.CreateProfile(ctx, &spec)
+}
+
+// Import creates a host profile named name, from the serialized form returned by Profile.ExportProfile.
+func (m HostProfileManager) Import(ctx context.Context, name string, annotation string, config string) (*HostProfile, error) {
+	spec := types.HostProfileSerializedHostProfileSpec{}
+
+	spec.Name = name
+	spec.Annotation = annotation
+	spec.Enabled = types.NewBool(true)
+	spec.ProfileConfigString = config
+
+	return m.CreateProfile(ctx, &spec)
+}
+
+// GenerateConfigTaskList returns the changes needed to apply the given host configuration,
+// as generated by HostProfile.ExecuteHostProfile.
+func (m HostProfileManager) GenerateConfigTaskList(ctx context.Context, spec types.HostConfigSpec, host *HostSystem) (*types.HostProfileManagerConfigTaskList, error) {
+	req := types.GenerateConfigTaskList{
+		This:       m.Reference(),
+		ConfigSpec: spec,
+		Host:       host.Reference(),
+	}
+
+	res, err := methods.GenerateConfigTaskList(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Returnval, nil
+}
+
+// ApplyHostConfig applies the given host configuration, as returned by GenerateConfigTaskList.
+func (m HostProfileManager) ApplyHostConfig(ctx context.Context, host *HostSystem, spec types.HostConfigSpec, input []types.ProfileDeferredPolicyOptionParameter) (*Task, error) {
+	req := types.ApplyHostConfig_Task{
+		This:       m.Reference(),
+		Host:       host.Reference(),
+		ConfigSpec: spec,
+		UserInput:  input,
+	}
+
+	res, err := methods.ApplyHostConfig_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
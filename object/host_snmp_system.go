@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type HostSnmpSystem struct {
+	Common
+}
+
+func NewHostSnmpSystem(c *vim25.Client, ref types.ManagedObjectReference) *HostSnmpSystem {
+	return &HostSnmpSystem{
+		Common: NewCommon(c, ref),
+	}
+}
+
+// Configuration returns the current SNMP agent configuration.
+func (s HostSnmpSystem) Configuration(ctx context.Context) (*types.HostSnmpConfigSpec, error) {
+	var hss mo.HostSnmpSystem
+
+	err := s.Properties(ctx, s.Reference(), []string{"configuration"}, &hss)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hss.Configuration, nil
+}
+
+func (s HostSnmpSystem) Limits(ctx context.Context) (*types.HostSnmpSystemAgentLimits, error) {
+	var hss mo.HostSnmpSystem
+
+	err := s.Properties(ctx, s.Reference(), []string{"limits"}, &hss)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hss.Limits, nil
+}
+
+// ReconfigureSnmpAgent replaces the SNMP agent configuration with spec.
+func (s HostSnmpSystem) ReconfigureSnmpAgent(ctx context.Context, spec types.HostSnmpConfigSpec) error {
+	req := types.ReconfigureSnmpAgent{
+		This: s.Reference(),
+		Spec: spec,
+	}
+
+	_, err := methods.ReconfigureSnmpAgent(ctx, s.c, &req)
+	return err
+}
+
+// SendTestNotification sends a test notification to all configured trap targets.
+func (s HostSnmpSystem) SendTestNotification(ctx context.Context) error {
+	req := types.SendTestNotification{
+		This: s.Reference(),
+	}
+
+	_, err := methods.SendTestNotification(ctx, s.c, &req)
+	return err
+}
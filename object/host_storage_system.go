@@ -143,3 +143,71 @@ func (s HostStorageSystem) MarkAsNonLocal(ctx context.Context, uuid string) (*Ta
 
 	return NewTask(s.c, res.Returnval), nil
 }
+
+func (s HostStorageSystem) UpdateSoftwareInternetScsiEnabled(ctx context.Context, enabled bool) error {
+	req := types.UpdateSoftwareInternetScsiEnabled{
+		This:    s.Reference(),
+		Enabled: enabled,
+	}
+
+	_, err := methods.UpdateSoftwareInternetScsiEnabled(ctx, s.c, &req)
+	return err
+}
+
+func (s HostStorageSystem) AddInternetScsiSendTargets(ctx context.Context, device string, targets []types.HostInternetScsiHbaSendTarget) error {
+	req := types.AddInternetScsiSendTargets{
+		This:           s.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+
+	_, err := methods.AddInternetScsiSendTargets(ctx, s.c, &req)
+	return err
+}
+
+func (s HostStorageSystem) RemoveInternetScsiSendTargets(ctx context.Context, device string, targets []types.HostInternetScsiHbaSendTarget) error {
+	req := types.RemoveInternetScsiSendTargets{
+		This:           s.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+
+	_, err := methods.RemoveInternetScsiSendTargets(ctx, s.c, &req)
+	return err
+}
+
+func (s HostStorageSystem) AddInternetScsiStaticTargets(ctx context.Context, device string, targets []types.HostInternetScsiHbaStaticTarget) error {
+	req := types.AddInternetScsiStaticTargets{
+		This:           s.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+
+	_, err := methods.AddInternetScsiStaticTargets(ctx, s.c, &req)
+	return err
+}
+
+func (s HostStorageSystem) RemoveInternetScsiStaticTargets(ctx context.Context, device string, targets []types.HostInternetScsiHbaStaticTarget) error {
+	req := types.RemoveInternetScsiStaticTargets{
+		This:           s.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+
+	_, err := methods.RemoveInternetScsiStaticTargets(ctx, s.c, &req)
+	return err
+}
+
+// UpdateInternetScsiAuthenticationProperties updates the CHAP settings of the given HBA,
+// or of the targets in set if it is non-nil.
+func (s HostStorageSystem) UpdateInternetScsiAuthenticationProperties(ctx context.Context, device string, props types.HostInternetScsiHbaAuthenticationProperties, set *types.HostInternetScsiHbaTargetSet) error {
+	req := types.UpdateInternetScsiAuthenticationProperties{
+		This:                     s.Reference(),
+		IScsiHbaDevice:           device,
+		AuthenticationProperties: props,
+		TargetSet:                set,
+	}
+
+	_, err := methods.UpdateInternetScsiAuthenticationProperties(ctx, s.c, &req)
+	return err
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/RotatingFans/govmomi/property"
 	"github.com/RotatingFans/govmomi/vim25"
 	"github.com/RotatingFans/govmomi/vim25/methods"
 	"github.com/RotatingFans/govmomi/vim25/mo"
@@ -151,3 +152,85 @@ func (h HostSystem) ExitMaintenanceMode(ctx context.Context, timeout int32) (*Ta
 
 	return NewTask(h.c, res.Returnval), nil
 }
+
+// Reboot reboots the host. If force is false, the host must be in maintenance mode.
+func (h HostSystem) Reboot(ctx context.Context, force bool) (*Task, error) {
+	req := types.RebootHost_Task{
+		This:  h.Reference(),
+		Force: force,
+	}
+
+	res, err := methods.RebootHost_Task(ctx, h.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(h.c, res.Returnval), nil
+}
+
+// Shutdown shuts down the host. If force is false, the host must be in maintenance mode.
+func (h HostSystem) Shutdown(ctx context.Context, force bool) (*Task, error) {
+	req := types.ShutdownHost_Task{
+		This:  h.Reference(),
+		Force: force,
+	}
+
+	res, err := methods.ShutdownHost_Task(ctx, h.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(h.c, res.Returnval), nil
+}
+
+// PowerDownToStandBy puts the host in standby mode, where it can be powered up remotely by VC.
+func (h HostSystem) PowerDownToStandBy(ctx context.Context, timeout int32, evacuate bool) (*Task, error) {
+	req := types.PowerDownHostToStandBy_Task{
+		This:                  h.Reference(),
+		TimeoutSec:            timeout,
+		EvacuatePoweredOffVms: types.NewBool(evacuate),
+	}
+
+	res, err := methods.PowerDownHostToStandBy_Task(ctx, h.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(h.c, res.Returnval), nil
+}
+
+func (h HostSystem) PowerUpFromStandBy(ctx context.Context, timeout int32) (*Task, error) {
+	req := types.PowerUpHostFromStandBy_Task{
+		This:       h.Reference(),
+		TimeoutSec: timeout,
+	}
+
+	res, err := methods.PowerUpHostFromStandBy_Task(ctx, h.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(h.c, res.Returnval), nil
+}
+
+// WaitForConnectionState waits for the host runtime.connectionState property to equal the given state.
+// If not is true, waits for the property to change to any other state instead.
+func (h HostSystem) WaitForConnectionState(ctx context.Context, state types.HostSystemConnectionState, not bool) error {
+	p := property.DefaultCollector(h.c)
+	return property.Wait(ctx, p, h.Reference(), []string{"runtime.connectionState"}, func(pc []types.PropertyChange) bool {
+		for _, c := range pc {
+			if c.Name != "runtime.connectionState" {
+				continue
+			}
+			if c.Op != types.PropertyChangeOpAssign {
+				continue
+			}
+
+			if s, ok := c.Val.(types.HostSystemConnectionState); ok {
+				return (s == state) != not
+			}
+		}
+
+		return false
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type IscsiManager struct {
+	Common
+}
+
+func NewIscsiManager(c *vim25.Client, ref types.ManagedObjectReference) *IscsiManager {
+	return &IscsiManager{
+		Common: NewCommon(c, ref),
+	}
+}
+
+// BindVnic binds the VMkernel nic to the iSCSI HBA for port binding.
+func (m IscsiManager) BindVnic(ctx context.Context, hba string, vnic string) error {
+	req := types.BindVnic{
+		This:         m.Reference(),
+		IScsiHbaName: hba,
+		VnicDevice:   vnic,
+	}
+
+	_, err := methods.BindVnic(ctx, m.c, &req)
+	return err
+}
+
+func (m IscsiManager) UnbindVnic(ctx context.Context, hba string, vnic string, force bool) error {
+	req := types.UnbindVnic{
+		This:         m.Reference(),
+		IScsiHbaName: hba,
+		VnicDevice:   vnic,
+		Force:        force,
+	}
+
+	_, err := methods.UnbindVnic(ctx, m.c, &req)
+	return err
+}
+
+func (m IscsiManager) QueryBoundVnics(ctx context.Context, hba string) ([]types.IscsiPortInfo, error) {
+	req := types.QueryBoundVnics{
+		This:         m.Reference(),
+		IScsiHbaName: hba,
+	}
+
+	res, err := methods.QueryBoundVnics(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+// Profile contains the methods common to HostProfile and ClusterProfile.
+type Profile struct {
+	Common
+}
+
+func NewProfile(c *vim25.Client, ref types.ManagedObjectReference) *Profile {
+	return &Profile{
+		Common: NewCommon(c, ref),
+	}
+}
+
+// AssociateProfile attaches the profile to the given entities.
+func (p Profile) AssociateProfile(ctx context.Context, entity []types.ManagedObjectReference) error {
+	req := types.AssociateProfile{
+		This:   p.Reference(),
+		Entity: entity,
+	}
+
+	_, err := methods.AssociateProfile(ctx, p.c, &req)
+	return err
+}
+
+// DissociateProfile detaches the profile from the given entities, or all entities if none are given.
+func (p Profile) DissociateProfile(ctx context.Context, entity []types.ManagedObjectReference) error {
+	req := types.DissociateProfile{
+		This:   p.Reference(),
+		Entity: entity,
+	}
+
+	_, err := methods.DissociateProfile(ctx, p.c, &req)
+	return err
+}
+
+// CheckProfileCompliance checks compliance of the given entities, or all attached entities if none are given.
+// The task result is of type types.ArrayOfComplianceResult.
+func (p Profile) CheckProfileCompliance(ctx context.Context, entity []types.ManagedObjectReference) (*Task, error) {
+	req := types.CheckProfileCompliance_Task{
+		This:   p.Reference(),
+		Entity: entity,
+	}
+
+	res, err := methods.CheckProfileCompliance_Task(ctx, p.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(p.c, res.Returnval), nil
+}
+
+// ExportProfile returns the serialized form of the profile.
+func (p Profile) ExportProfile(ctx context.Context) (string, error) {
+	req := types.ExportProfile{
+		This: p.Reference(),
+	}
+
+	res, err := methods.ExportProfile(ctx, p.c, &req)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Returnval, nil
+}
+
+func (p Profile) Destroy(ctx context.Context) error {
+	req := types.DestroyProfile{
+		This: p.Reference(),
+	}
+
+	_, err := methods.DestroyProfile(ctx, p.c, &req)
+	return err
+}
+
+type HostProfile struct {
+	Profile
+}
+
+func NewHostProfile(c *vim25.Client, ref types.ManagedObjectReference) *HostProfile {
+	return &HostProfile{
+		Profile: *NewProfile(c, ref),
+	}
+}
+
+// ExecuteHostProfile generates the host configuration for host, which can be passed to
+// HostProfileManager.GenerateConfigTaskList and HostProfileManager.ApplyHostConfig.
+func (p HostProfile) ExecuteHostProfile(ctx context.Context, host *HostSystem, input []types.ProfileDeferredPolicyOptionParameter) (*types.ProfileExecuteResult, error) {
+	req := types.ExecuteHostProfile{
+		This:          p.Reference(),
+		Host:          host.Reference(),
+		DeferredParam: input,
+	}
+
+	res, err := methods.ExecuteHostProfile(ctx, p.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Returnval, nil
+}
+
+// UpdateReferenceHost changes the reference host of the profile, or unsets it if host is nil.
+func (p HostProfile) UpdateReferenceHost(ctx context.Context, host *HostSystem) error {
+	req := types.UpdateReferenceHost{
+		This: p.Reference(),
+	}
+
+	if host != nil {
+		ref := host.Reference()
+		req.Host = &ref
+	}
+
+	_, err := methods.UpdateReferenceHost(ctx, p.c, &req)
+	return err
+}
+
+func (p HostProfile) UpdateHostProfile(ctx context.Context, config types.BaseHostProfileConfigSpec) error {
+	req := types.UpdateHostProfile{
+		This:   p.Reference(),
+		Config: config,
+	}
+
+	_, err := methods.UpdateHostProfile(ctx, p.c, &req)
+	return err
+}
+
+type ClusterProfile struct {
+	Profile
+}
+
+func NewClusterProfile(c *vim25.Client, ref types.ManagedObjectReference) *ClusterProfile {
+	return &ClusterProfile{
+		Profile: *NewProfile(c, ref),
+	}
+}
+
+func (p ClusterProfile) UpdateClusterProfile(ctx context.Context, config types.BaseClusterProfileConfigSpec) error {
+	req := types.UpdateClusterProfile{
+		This:   p.Reference(),
+		Config: config,
+	}
+
+	_, err := methods.UpdateClusterProfile(ctx, p.c, &req)
+	return err
+}
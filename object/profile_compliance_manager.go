@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type ProfileComplianceManager struct {
+	Common
+}
+
+// GetProfileComplianceManager wraps NewProfileComplianceManager, returning ErrNotSupported
+// when the client is not connected to a vCenter instance.
+func GetProfileComplianceManager(c *vim25.Client) (*ProfileComplianceManager, error) {
+	if c.ServiceContent.ComplianceManager == nil {
+		return nil, ErrNotSupported
+	}
+	return NewProfileComplianceManager(c), nil
+}
+
+func NewProfileComplianceManager(c *vim25.Client) *ProfileComplianceManager {
+	m := ProfileComplianceManager{
+		Common: NewCommon(c, *c.ServiceContent.ComplianceManager),
+	}
+
+	return &m
+}
+
+// CheckCompliance checks compliance of the given entities against the given profiles.
+// If profile is empty, the profiles attached to each entity are used.
+// If entity is empty, the entities attached to each profile are checked.
+// The task result is of type types.ArrayOfComplianceResult.
+func (m ProfileComplianceManager) CheckCompliance(ctx context.Context, profile []types.ManagedObjectReference, entity []types.ManagedObjectReference) (*Task, error) {
+	req := types.CheckCompliance_Task{
+		This:    m.Reference(),
+		Profile: profile,
+		Entity:  entity,
+	}
+
+	res, err := methods.CheckCompliance_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
+
+// QueryComplianceStatus returns the results of the last compliance check.
+func (m ProfileComplianceManager) QueryComplianceStatus(ctx context.Context, profile []types.ManagedObjectReference, entity []types.ManagedObjectReference) ([]types.ComplianceResult, error) {
+	req := types.QueryComplianceStatus{
+		This:    m.Reference(),
+		Profile: profile,
+		Entity:  entity,
+	}
+
+	res, err := methods.QueryComplianceStatus(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+func (m ProfileComplianceManager) ClearComplianceStatus(ctx context.Context, profile []types.ManagedObjectReference, entity []types.ManagedObjectReference) error {
+	req := types.ClearComplianceStatus{
+		This:    m.Reference(),
+		Profile: profile,
+		Entity:  entity,
+	}
+
+	_, err := methods.ClearComplianceStatus(ctx, m.c, &req)
+	return err
+}
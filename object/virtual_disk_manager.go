@@ -17,6 +17,9 @@ limitations under the License.
 package object
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/RotatingFans/govmomi/vim25"
 	"github.com/RotatingFans/govmomi/vim25/methods"
 	"github.com/RotatingFans/govmomi/vim25/types"
@@ -143,3 +146,189 @@ func (m VirtualDiskManager) DeleteVirtualDisk(ctx context.Context, name string,
 
 	return NewTask(m.c, res.Returnval), nil
 }
+
+// ExtendVirtualDisk expands the capacity of a virtual disk to the new capacity.
+// If eagerZero is true, the extended part of the disk will be explicitly filled with zeroes.
+func (m VirtualDiskManager) ExtendVirtualDisk(
+	ctx context.Context,
+	name string, datacenter *Datacenter,
+	capacityKb int64, eagerZero *bool) (*Task, error) {
+
+	req := types.ExtendVirtualDisk_Task{
+		This:          m.Reference(),
+		Name:          name,
+		NewCapacityKb: capacityKb,
+		EagerZero:     eagerZero,
+	}
+
+	if datacenter != nil {
+		ref := datacenter.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.ExtendVirtualDisk_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
+
+// InflateVirtualDisk inflates a sparse or thin-provisioned virtual disk up to the full size.
+func (m VirtualDiskManager) InflateVirtualDisk(ctx context.Context, name string, dc *Datacenter) (*Task, error) {
+	req := types.InflateVirtualDisk_Task{
+		This: m.Reference(),
+		Name: name,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.InflateVirtualDisk_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
+
+// EagerZeroVirtualDisk explicitly zeroes out a lazy zeroed thick virtual disk,
+// converting it to eager zeroed thick.
+func (m VirtualDiskManager) EagerZeroVirtualDisk(ctx context.Context, name string, dc *Datacenter) (*Task, error) {
+	req := types.EagerZeroVirtualDisk_Task{
+		This: m.Reference(),
+		Name: name,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.EagerZeroVirtualDisk_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
+
+// ShrinkVirtualDisk shrinks a sparse virtual disk.
+// If copy is true, the disk is shrunk by copying into a new disk, which requires additional space.
+func (m VirtualDiskManager) ShrinkVirtualDisk(ctx context.Context, name string, dc *Datacenter, copy *bool) (*Task, error) {
+	req := types.ShrinkVirtualDisk_Task{
+		This: m.Reference(),
+		Name: name,
+		Copy: copy,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.ShrinkVirtualDisk_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
+
+// ZeroFillVirtualDisk overwrites all blocks of a virtual disk with zeroes.
+func (m VirtualDiskManager) ZeroFillVirtualDisk(ctx context.Context, name string, dc *Datacenter) (*Task, error) {
+	req := types.ZeroFillVirtualDisk_Task{
+		This: m.Reference(),
+		Name: name,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.ZeroFillVirtualDisk_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
+
+// QueryVirtualDiskUuid returns the UUID of a virtual disk.
+func (m VirtualDiskManager) QueryVirtualDiskUuid(ctx context.Context, name string, dc *Datacenter) (string, error) {
+	req := types.QueryVirtualDiskUuid{
+		This: m.Reference(),
+		Name: name,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.QueryVirtualDiskUuid(ctx, m.c, &req)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Returnval, nil
+}
+
+// SetVirtualDiskUuid sets the UUID of a virtual disk.
+func (m VirtualDiskManager) SetVirtualDiskUuid(ctx context.Context, name string, dc *Datacenter, uuid string) error {
+	req := types.SetVirtualDiskUuid{
+		This: m.Reference(),
+		Name: name,
+		Uuid: uuid,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	_, err := methods.SetVirtualDiskUuid(ctx, m.c, &req)
+	return err
+}
+
+// QueryVirtualDiskInfo returns information about a virtual disk, such as its type.
+// If includeParents is true, the parents of a delta disk are included in the result,
+// child first, making up the disk chain.
+func (m VirtualDiskManager) QueryVirtualDiskInfo(ctx context.Context, name string, dc *Datacenter, includeParents bool) ([]types.VirtualDiskInfo, error) {
+	req := types.QueryVirtualDiskInfo_Task{
+		This:           m.Reference(),
+		Name:           name,
+		IncludeParents: includeParents,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.QueryVirtualDiskInfo_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New("no QueryVirtualDiskInfo_Task response")
+	}
+
+	info, err := NewTask(m.c, res.Returnval).WaitForResult(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := info.Result.(type) {
+	case types.ArrayOfVirtualDiskInfo:
+		return result.VirtualDiskInfo, nil
+	case *types.ArrayOfVirtualDiskInfo:
+		return result.VirtualDiskInfo, nil
+	}
+
+	return nil, fmt.Errorf("unexpected result type %T", info.Result)
+}
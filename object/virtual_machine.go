@@ -18,6 +18,7 @@ package object
 
 import (
 	"errors"
+	"fmt"
 	"net"
 
 	"github.com/RotatingFans/govmomi/property"
@@ -395,6 +396,32 @@ func (v VirtualMachine) RemoveDevice(ctx context.Context, keepFiles bool, device
 	return v.configureDevice(ctx, types.VirtualDeviceConfigSpecOperationRemove, fop, device...)
 }
 
+// ExtendDisk grows the given (existing) disk to capacityInKB, which can be done while the VM is powered on.
+// The underlying disk file is left in place, unlike EditDevice which replaces the file backing.
+func (v VirtualMachine) ExtendDisk(ctx context.Context, disk *types.VirtualDisk, capacityInKB int64) error {
+	if capacityInKB <= disk.CapacityInKB {
+		return fmt.Errorf("new capacity (%dKB) must be larger than the current capacity (%dKB)", capacityInKB, disk.CapacityInKB)
+	}
+
+	disk.CapacityInKB = capacityInKB
+
+	spec := types.VirtualMachineConfigSpec{
+		DeviceChange: []types.BaseVirtualDeviceConfigSpec{
+			&types.VirtualDeviceConfigSpec{
+				Device:    disk,
+				Operation: types.VirtualDeviceConfigSpecOperationEdit,
+			},
+		},
+	}
+
+	task, err := v.Reconfigure(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
+
 // BootOptions returns the VirtualMachine's config.bootOptions property.
 func (v VirtualMachine) BootOptions(ctx context.Context) (*types.VirtualMachineBootOptions, error) {
 	var o mo.VirtualMachine
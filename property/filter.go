@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/RotatingFans/govmomi/vim25/types"
+)
+
+// Filter provides methods for matching against types.DynamicProperty
+//
+// Filter values are strings, matched against the string form of the property value.
+// String values support wildcard patterns as supported by path.Match, such as "web-*".
+// Numeric values can be prefixed with a comparison operator: ">", ">=", "<", "<=" or "!=".
+// Array values, such as ArrayOfString, match if any element matches.
+type Filter map[string]string
+
+// Keys returns the Filter map keys as a []string
+func (f Filter) Keys() []string {
+	keys := make([]string, 0, len(f))
+
+	for key := range f {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// MatchProperty returns true if a Filter entry matches the given prop.
+func (f Filter) MatchProperty(prop types.DynamicProperty) bool {
+	match, ok := f[prop.Name]
+	if !ok {
+		return false
+	}
+
+	return matchValue(match, reflect.ValueOf(prop.Val))
+}
+
+func matchValue(match string, v reflect.Value) bool {
+	if !v.IsValid() {
+		return match == ""
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return matchValue(match, v.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if matchValue(match, v.Index(i)) {
+				return true
+			}
+		}
+		return false
+	case reflect.Struct:
+		// ArrayOf* types have a single slice field
+		if v.NumField() == 1 && v.Field(0).Kind() == reflect.Slice {
+			return matchValue(match, v.Field(0))
+		}
+		if ref, ok := v.Interface().(types.ManagedObjectReference); ok {
+			return match == ref.String() || match == ref.Value
+		}
+		return false
+	case reflect.Bool:
+		b, err := strconv.ParseBool(match)
+		return err == nil && b == v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return matchNumber(match, float64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return matchNumber(match, float64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return matchNumber(match, v.Float())
+	case reflect.String:
+		// Includes enum types, such as VirtualMachinePowerState
+		ok, err := path.Match(match, v.String())
+		return ok && err == nil
+	}
+
+	return match == fmt.Sprint(v.Interface())
+}
+
+func matchNumber(match string, n float64) bool {
+	ops := []string{">=", "<=", "!=", ">", "<", "="}
+
+	op := "="
+	for _, o := range ops {
+		if strings.HasPrefix(match, o) {
+			op = o
+			match = match[len(o):]
+			break
+		}
+	}
+
+	m, err := strconv.ParseFloat(strings.TrimSpace(match), 64)
+	if err != nil {
+		return false
+	}
+
+	switch op {
+	case ">=":
+		return n >= m
+	case "<=":
+		return n <= m
+	case "!=":
+		return n != m
+	case ">":
+		return n > m
+	case "<":
+		return n < m
+	default:
+		return n == m
+	}
+}
+
+// MatchPropertyList returns true if all given props match the Filter.
+func (f Filter) MatchPropertyList(props []types.DynamicProperty) bool {
+	for _, p := range props {
+		if !f.MatchProperty(p) {
+			return false
+		}
+	}
+
+	return len(f) == len(props)
+}
+
+// MatchObjectContent returns a list of ObjectContent.Obj where the ObjectContent.PropSet matches the Filter.
+func (f Filter) MatchObjectContent(objects []types.ObjectContent) []types.ManagedObjectReference {
+	var refs []types.ManagedObjectReference
+
+	for _, o := range objects {
+		if f.MatchPropertyList(o.PropSet) {
+			refs = append(refs, o.Obj)
+		}
+	}
+
+	return refs
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property
+
+import (
+	"testing"
+
+	"github.com/RotatingFans/govmomi/vim25/types"
+)
+
+func TestFilterMatchProperty(t *testing.T) {
+	tests := []struct {
+		match  string
+		val    types.AnyType
+		expect bool
+	}{
+		{"web-*", "web-01", true},
+		{"web-*", "db-01", false},
+		{"poweredOn", types.VirtualMachinePowerStatePoweredOn, true},
+		{"poweredOn", types.VirtualMachinePowerStatePoweredOff, false},
+		{">4", int32(8), true},
+		{">4", int32(4), false},
+		{">=4", int32(4), true},
+		{"<=1024", int64(2048), false},
+		{"!=2", int32(1), true},
+		{"4", int32(4), true},
+		{"true", true, true},
+		{"false", true, false},
+		{"10.0.0.*", types.ArrayOfString{String: []string{"fe80::1", "10.0.0.5"}}, true},
+		{"datastore-1", types.ManagedObjectReference{Type: "Datastore", Value: "datastore-1"}, true},
+	}
+
+	for _, test := range tests {
+		f := Filter{"prop": test.match}
+		prop := types.DynamicProperty{Name: "prop", Val: test.val}
+
+		if f.MatchProperty(prop) != test.expect {
+			t.Errorf("%s %#v: expected %t", test.match, test.val, test.expect)
+		}
+	}
+}
+
+func TestFilterMatchObjectContent(t *testing.T) {
+	f := Filter{"name": "vm*", "runtime.powerState": "poweredOn"}
+
+	objects := []types.ObjectContent{
+		{
+			Obj: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"},
+			PropSet: []types.DynamicProperty{
+				{Name: "name", Val: "vm1"},
+				{Name: "runtime.powerState", Val: types.VirtualMachinePowerStatePoweredOn},
+			},
+		},
+		{
+			Obj: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-2"},
+			PropSet: []types.DynamicProperty{
+				{Name: "name", Val: "vm2"},
+				{Name: "runtime.powerState", Val: types.VirtualMachinePowerStatePoweredOff},
+			},
+		},
+		{
+			Obj: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-3"},
+			PropSet: []types.DynamicProperty{
+				{Name: "name", Val: "vm3"},
+			},
+		},
+	}
+
+	refs := f.MatchObjectContent(objects)
+	if len(refs) != 1 || refs[0].Value != "vm-1" {
+		t.Errorf("refs=%v", refs)
+	}
+}
@@ -34,6 +34,7 @@ import (
 // The newly created collector is destroyed before this function returns (both
 // in case of success or error).
 //
+// If ps is nil, updates for all properties are received.
 func Wait(ctx context.Context, c *Collector, obj types.ManagedObjectReference, ps []string, f func([]types.PropertyChange) bool) error {
 	p, err := c.Create(ctx)
 	if err != nil {
@@ -60,6 +61,10 @@ func Wait(ctx context.Context, c *Collector, obj types.ManagedObjectReference, p
 		},
 	}
 
+	if ps == nil {
+		req.Spec.PropSet[0].All = types.NewBool(true)
+	}
+
 	err = p.CreateFilter(ctx, req)
 	if err != nil {
 		return err
@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package view
+
+import (
+	"github.com/RotatingFans/govmomi/object"
+	"github.com/RotatingFans/govmomi/property"
+	"github.com/RotatingFans/govmomi/vim25"
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/mo"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type ContainerView struct {
+	object.Common
+}
+
+func NewContainerView(c *vim25.Client, ref types.ManagedObjectReference) *ContainerView {
+	return &ContainerView{
+		Common: object.NewCommon(c, ref),
+	}
+}
+
+func (v ContainerView) Destroy(ctx context.Context) error {
+	req := types.DestroyView{
+		This: v.Reference(),
+	}
+
+	_, err := methods.DestroyView(ctx, v.Client(), &req)
+	return err
+}
+
+// RetrieveProperties retrieves the properties ps of all objects in the view of the given types.
+// If kind is empty, ManagedEntity is used.  If ps is nil, all properties are retrieved.
+func (v ContainerView) RetrieveProperties(ctx context.Context, kind []string, ps []string) ([]types.ObjectContent, error) {
+	if len(kind) == 0 {
+		kind = []string{"ManagedEntity"}
+	}
+
+	spec := types.PropertyFilterSpec{
+		ObjectSet: []types.ObjectSpec{
+			{
+				Obj:  v.Reference(),
+				Skip: types.NewBool(true),
+				SelectSet: []types.BaseSelectionSpec{
+					&types.TraversalSpec{
+						Type: "ContainerView",
+						Path: "view",
+					},
+				},
+			},
+		},
+	}
+
+	for _, t := range kind {
+		pspec := types.PropertySpec{
+			Type: t,
+		}
+
+		if ps == nil {
+			pspec.All = types.NewBool(true)
+		} else {
+			pspec.PathSet = ps
+		}
+
+		spec.PropSet = append(spec.PropSet, pspec)
+	}
+
+	pc := property.DefaultCollector(v.Client())
+
+	res, err := pc.RetrieveProperties(ctx, types.RetrieveProperties{SpecSet: []types.PropertyFilterSpec{spec}})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+// Retrieve populates dst as property.Collector.Retrieve does, for all objects in the view of the given types.
+func (v ContainerView) Retrieve(ctx context.Context, kind []string, ps []string, dst interface{}) error {
+	objects, err := v.RetrieveProperties(ctx, kind, ps)
+	if err != nil {
+		return err
+	}
+
+	return mo.LoadRetrievePropertiesResponse(&types.RetrievePropertiesResponse{Returnval: objects}, dst)
+}
+
+// Find returns object references for objects in the view of the given types,
+// where the filter properties match.
+func (v ContainerView) Find(ctx context.Context, kind []string, filter property.Filter) ([]types.ManagedObjectReference, error) {
+	ps := filter.Keys()
+	if len(ps) == 0 {
+		ps = []string{"name"}
+		filter = property.Filter{"name": "*"}
+	}
+
+	objects, err := v.RetrieveProperties(ctx, kind, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.MatchObjectContent(objects), nil
+}
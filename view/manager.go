@@ -49,3 +49,21 @@ func (m Manager) CreateListView(ctx context.Context, objects []types.ManagedObje
 
 	return NewListView(m.Client(), res.Returnval), nil
 }
+
+// CreateContainerView creates a view of the objects of the given types in container.
+// If recursive is true, the view includes objects of all descendant containers.
+func (m Manager) CreateContainerView(ctx context.Context, container types.ManagedObjectReference, kind []string, recursive bool) (*ContainerView, error) {
+	req := types.CreateContainerView{
+		This:      m.Common.Reference(),
+		Container: container,
+		Recursive: recursive,
+		Type:      kind,
+	}
+
+	res, err := methods.CreateContainerView(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewContainerView(m.Client(), res.Returnval), nil
+}
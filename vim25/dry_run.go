@@ -0,0 +1,379 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vim25
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/RotatingFans/govmomi/vim25/soap"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"github.com/RotatingFans/govmomi/vim25/xml"
+	"golang.org/x/net/context"
+)
+
+// DryRunFormat is the encoding used to log intercepted requests.
+type DryRunFormat int
+
+const (
+	DryRunXML DryRunFormat = iota
+	DryRunJSON
+)
+
+// readOnlyPrefixes are prefixes of method names that do not change state,
+// these methods are sent to the server when running dry.
+var readOnlyPrefixes = []string{
+	"Acquire",
+	"Browse",
+	"CheckForUpdates",
+	"CurrentTime",
+	"Does",
+	"Fetch",
+	"Find",
+	"Get",
+	"Has",
+	"List",
+	"Login",
+	"Logout",
+	"Parse",
+	"Query",
+	"ReadNext",
+	"ReadPrevious",
+	"Retrieve",
+	"Search",
+	"SessionIsActive",
+	"Validate",
+	"WaitForUpdates",
+}
+
+// readOnlyMethods are methods that only create or destroy client side state,
+// such as property collectors and views.
+var readOnlyMethods = map[string]bool{
+	"CancelWaitForUpdates":       true,
+	"CreateCollectorForEvents":   true,
+	"CreateCollectorForTasks":    true,
+	"CreateContainerView":        true,
+	"CreateFilter":               true,
+	"CreateInventoryView":        true,
+	"CreateListView":             true,
+	"CreatePropertyCollector":    true,
+	"CustomizationSpecItemToXml": true,
+	"DestroyCollector":           true,
+	"DestroyPropertyCollector":   true,
+	"DestroyPropertyFilter":      true,
+	"DestroyView":                true,
+	"ResetCollector":             true,
+	"RewindCollector":            true,
+	"SetCollectorPageSize":       true,
+}
+
+// taskResultTypes are the managed object types of task results that callers depend on.
+var taskResultTypes = map[string]string{
+	"CloneVM_Task":        "VirtualMachine",
+	"CreateChildVM_Task":  "VirtualMachine",
+	"CreateSnapshot_Task": "VirtualMachineSnapshot",
+	"CreateVM_Task":       "VirtualMachine",
+	"RegisterVM_Task":     "VirtualMachine",
+}
+
+// IsReadOnlyMethod returns true if the method with the given name does not change state.
+func IsReadOnlyMethod(name string) bool {
+	if readOnlyMethods[name] {
+		return true
+	}
+
+	for _, prefix := range readOnlyPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isReadOnlyRequest returns true if the given method request does not change state.
+// ExecuteSoap requests, as used by esxcli, are read-only if the method they execute is,
+// for example "vim.EsxCLI.network.ip.interface.list".
+func isReadOnlyRequest(method string, req interface{}) bool {
+	if r, ok := req.(*types.ExecuteSoap); ok {
+		name := r.Method[strings.LastIndex(r.Method, ".")+1:]
+		if name == "" {
+			return false
+		}
+		return IsReadOnlyMethod(strings.ToUpper(name[:1]) + name[1:])
+	}
+
+	return IsReadOnlyMethod(method)
+}
+
+type dryRun struct {
+	roundTripper soap.RoundTripper
+
+	out    io.Writer
+	format DryRunFormat
+
+	mu      sync.Mutex
+	n       int
+	tasks   map[string]types.TaskInfo
+	filters map[string]types.ManagedObjectReference
+	waits   map[string]types.ManagedObjectReference
+}
+
+// DryRun wraps the specified soap.RoundTripper, sending only read-only methods
+// to the server.  Any other method request is written to w in the given format
+// and a zero value response is returned instead.  Methods that return a task
+// return a synthetic task, which completes successfully when waited on.
+func DryRun(roundTripper soap.RoundTripper, w io.Writer, format DryRunFormat) soap.RoundTripper {
+	return &dryRun{
+		roundTripper: roundTripper,
+		out:          w,
+		format:       format,
+		tasks:        make(map[string]types.TaskInfo),
+		filters:      make(map[string]types.ManagedObjectReference),
+		waits:        make(map[string]types.ManagedObjectReference),
+	}
+}
+
+// body returns the request or response field of a method body.
+func body(v soap.HasFault, name string) reflect.Value {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return rv.FieldByName(name)
+}
+
+// newResponse sets the response field of res to a new zero value and returns it.
+func newResponse(res soap.HasFault) reflect.Value {
+	field := body(res, "Res")
+	if !field.IsValid() || field.Kind() != reflect.Ptr {
+		return reflect.Value{}
+	}
+
+	field.Set(reflect.New(field.Type().Elem()))
+
+	return field.Elem()
+}
+
+func setReturnval(res soap.HasFault, val interface{}) {
+	rv := newResponse(res)
+	if !rv.IsValid() {
+		return
+	}
+
+	field := rv.FieldByName("Returnval")
+	if !field.IsValid() {
+		return
+	}
+
+	v := reflect.ValueOf(val)
+	if field.Kind() == reflect.Ptr {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+
+	field.Set(v)
+}
+
+func (d *dryRun) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	field := body(req, "Req")
+	if !field.IsValid() || field.IsNil() {
+		return d.roundTripper.RoundTrip(ctx, req, res)
+	}
+
+	method := field.Elem().Type().Name()
+
+	if d.intercept(field.Interface(), res) {
+		return nil
+	}
+
+	if isReadOnlyRequest(method, field.Interface()) {
+		return d.roundTripper.RoundTrip(ctx, req, res)
+	}
+
+	if err := d.log(method, field.Interface()); err != nil {
+		return err
+	}
+
+	rv := newResponse(res)
+	if !rv.IsValid() {
+		return nil
+	}
+
+	returnval := rv.FieldByName("Returnval")
+
+	if strings.HasSuffix(method, "_Task") && returnval.IsValid() && returnval.Type() == reflect.TypeOf(types.ManagedObjectReference{}) {
+		returnval.Set(reflect.ValueOf(d.newTask(method, field.Elem().FieldByName("This"))))
+	}
+
+	return nil
+}
+
+// newTask creates a synthetic task, in the success state.
+func (d *dryRun) newTask(method string, this reflect.Value) types.ManagedObjectReference {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.n++
+
+	ref := types.ManagedObjectReference{
+		Type:  "Task",
+		Value: fmt.Sprintf("dry-run-task-%d", d.n),
+	}
+
+	info := types.TaskInfo{
+		Key:           ref.Value,
+		Task:          ref,
+		Name:          method,
+		DescriptionId: method,
+		State:         types.TaskInfoStateSuccess,
+		Progress:      100,
+	}
+
+	if this.IsValid() {
+		if obj, ok := this.Interface().(types.ManagedObjectReference); ok {
+			info.Entity = &obj
+			info.EntityName = obj.Value
+		}
+	}
+
+	if kind, ok := taskResultTypes[method]; ok {
+		info.Result = types.ManagedObjectReference{
+			Type:  kind,
+			Value: fmt.Sprintf("dry-run-%d", d.n),
+		}
+	}
+
+	d.tasks[ref.Value] = info
+
+	return ref
+}
+
+// intercept handles property collector methods that reference synthetic tasks,
+// returning true if the request was handled.
+func (d *dryRun) intercept(req interface{}, res soap.HasFault) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.tasks) == 0 {
+		return false
+	}
+
+	switch r := req.(type) {
+	case *types.CreateFilter:
+		for _, o := range r.Spec.ObjectSet {
+			if _, ok := d.tasks[o.Obj.Value]; ok && o.Obj.Type == "Task" {
+				d.n++
+				filter := types.ManagedObjectReference{
+					Type:  "PropertyFilter",
+					Value: fmt.Sprintf("dry-run-filter-%d", d.n),
+				}
+
+				d.filters[filter.Value] = o.Obj
+				d.waits[r.This.Value] = filter
+				setReturnval(res, filter)
+				return true
+			}
+		}
+	case *types.DestroyPropertyFilter:
+		if _, ok := d.filters[r.This.Value]; ok {
+			delete(d.filters, r.This.Value)
+			newResponse(res)
+			return true
+		}
+	case *types.WaitForUpdatesEx:
+		if filter, ok := d.waits[r.This.Value]; ok {
+			setReturnval(res, d.update(filter))
+			return true
+		}
+	case *types.WaitForUpdates:
+		if filter, ok := d.waits[r.This.Value]; ok {
+			setReturnval(res, d.update(filter))
+			return true
+		}
+	case *types.DestroyPropertyCollector:
+		delete(d.waits, r.This.Value)
+	}
+
+	return false
+}
+
+func (d *dryRun) update(filter types.ManagedObjectReference) types.UpdateSet {
+	ref := d.filters[filter.Value]
+
+	return types.UpdateSet{
+		Version: "1",
+		FilterSet: []types.PropertyFilterUpdate{
+			{
+				Filter: filter,
+				ObjectSet: []types.ObjectUpdate{
+					{
+						Kind: types.ObjectUpdateKindEnter,
+						Obj:  ref,
+						ChangeSet: []types.PropertyChange{
+							{
+								Name: "info",
+								Op:   types.PropertyChangeOpAssign,
+								Val:  d.tasks[ref.Value],
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dryRun) log(method string, req interface{}) error {
+	var b []byte
+	var err error
+
+	switch d.format {
+	case DryRunJSON:
+		b, err = json.MarshalIndent(req, "", "  ")
+	default:
+		start := xml.StartElement{Name: xml.Name{Space: "urn:vim25", Local: method}}
+		var buf bytes.Buffer
+		enc := xml.NewEncoder(&buf)
+		enc.Indent("", "  ")
+		if err = enc.EncodeElement(req, start); err == nil {
+			err = enc.Flush()
+		}
+		b = buf.Bytes()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, err = fmt.Fprintf(d.out, "# dry-run: %s\n%s\n", method, b)
+	return err
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2016 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vim25
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/RotatingFans/govmomi/vim25/methods"
+	"github.com/RotatingFans/govmomi/vim25/soap"
+	"github.com/RotatingFans/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type recordRoundTripper struct {
+	calls int
+}
+
+func (r *recordRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	r.calls++
+	return nil
+}
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	tests := map[string]bool{
+		"RetrieveProperties":         true,
+		"WaitForUpdatesEx":           true,
+		"FindByInventoryPath":        true,
+		"QueryVirtualDiskUuid":       true,
+		"CreatePropertyCollector":    true,
+		"ListFilesInGuest":           true,
+		"ListProcessesInGuest":       true,
+		"ListGuestAliases":           true,
+		"ListRegistryKeysInGuest":    true,
+		"ListCACertificates":         true,
+		"GetCustomizationSpec":       true,
+		"GetAlarm":                   true,
+		"DoesCustomizationSpecExist": true,
+		"CustomizationSpecItemToXml": true,
+		"ParseDescriptor":            true,
+		"ValidateHost":               true,
+		"ValidateCredentialsInGuest": true,
+		"Destroy_Task":               false,
+		"DeleteDatastoreFile_Task":   false,
+		"CreateFolder":               false,
+		"PowerOnVM_Task":             false,
+	}
+
+	for name, expect := range tests {
+		if IsReadOnlyMethod(name) != expect {
+			t.Errorf("%s: expected %t", name, expect)
+		}
+	}
+}
+
+func TestIsReadOnlyRequest(t *testing.T) {
+	tests := map[string]bool{
+		"vim.CLIInfo.FetchCLIInfo":               true,
+		"vim.EsxCLI.network.ip.interface.list":   true,
+		"vim.EsxCLI.system.hostname.get":         true,
+		"vim.EsxCLI.system.hostname.set":         false,
+		"vim.EsxCLI.network.ip.interface.remove": false,
+		"":                                       false,
+	}
+
+	for method, expect := range tests {
+		req := &types.ExecuteSoap{Method: method}
+		if isReadOnlyRequest("ExecuteSoap", req) != expect {
+			t.Errorf("%s: expected %t", method, expect)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	rec := &recordRoundTripper{}
+
+	for _, format := range []DryRunFormat{DryRunXML, DryRunJSON} {
+		var out bytes.Buffer
+		rt := DryRun(rec, &out, format)
+
+		rec.calls = 0
+
+		_, err := methods.RetrieveProperties(ctx, rt, &types.RetrieveProperties{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rec.calls != 1 || out.Len() != 0 {
+			t.Errorf("read-only method was not sent")
+		}
+
+		vm := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-42"}
+
+		res, err := methods.Destroy_Task(ctx, rt, &types.Destroy_Task{This: vm})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rec.calls != 1 {
+			t.Errorf("mutating method was sent")
+		}
+
+		if !strings.HasPrefix(out.String(), "# dry-run: Destroy_Task\n") || !strings.Contains(out.String(), "vm-42") {
+			t.Errorf("unexpected output: %s", out.String())
+		}
+
+		task := res.Returnval
+		if task.Type != "Task" {
+			t.Fatalf("unexpected task: %s", task)
+		}
+
+		// Waiting for the task is handled without sending to the server
+		pc := types.ManagedObjectReference{Type: "PropertyCollector", Value: "session[1]"}
+
+		_, err = methods.CreateFilter(ctx, rt, &types.CreateFilter{
+			This: pc,
+			Spec: types.PropertyFilterSpec{
+				ObjectSet: []types.ObjectSpec{{Obj: task}},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wres, err := methods.WaitForUpdatesEx(ctx, rt, &types.WaitForUpdatesEx{This: pc})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rec.calls != 1 {
+			t.Errorf("task wait was sent")
+		}
+
+		info := wres.Returnval.FilterSet[0].ObjectSet[0].ChangeSet[0].Val.(types.TaskInfo)
+		if info.State != types.TaskInfoStateSuccess || info.Entity.Value != "vm-42" {
+			t.Errorf("unexpected task info: %#v", info)
+		}
+	}
+}
@@ -121,3 +121,28 @@ func ExecuteSoap(ctx context.Context, r soap.RoundTripper, req *types.ExecuteSoa
 
 	return resBody.Res, nil
 }
+
+type QueryVirtualDiskInfo_TaskBody struct {
+	Req         *types.QueryVirtualDiskInfo_Task         `xml:"urn:internalvim25 QueryVirtualDiskInfo_Task,omitempty"`
+	Res         *types.QueryVirtualDiskInfo_TaskResponse `xml:"urn:vim25 QueryVirtualDiskInfo_TaskResponse,omitempty"`
+	InternalRes *types.QueryVirtualDiskInfo_TaskResponse `xml:"urn:internalvim25 QueryVirtualDiskInfo_TaskResponse,omitempty"`
+	Fault_      *soap.Fault
+}
+
+func (b *QueryVirtualDiskInfo_TaskBody) Fault() *soap.Fault { return b.Fault_ }
+
+func QueryVirtualDiskInfo_Task(ctx context.Context, r soap.RoundTripper, req *types.QueryVirtualDiskInfo_Task) (*types.QueryVirtualDiskInfo_TaskResponse, error) {
+	var reqBody, resBody QueryVirtualDiskInfo_TaskBody
+
+	reqBody.Req = req
+
+	if err := r.RoundTrip(ctx, &reqBody, &resBody); err != nil {
+		return nil, err
+	}
+
+	if resBody.Res != nil {
+		return resBody.Res, nil
+	}
+
+	return resBody.InternalRes, nil
+}
@@ -18,7 +18,9 @@ package soap
 
 import (
 	"bytes"
+	"crypto/sha1"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -58,6 +60,8 @@ type Client struct {
 	t *http.Transport
 	p *url.URL
 
+	thumbprint string
+
 	Namespace string // Vim namespace
 	Version   string // Vim version
 }
@@ -176,22 +180,77 @@ func (c *Client) SetCertificate(cert tls.Certificate) {
 	c.u.Host = sdkTunnel
 }
 
+// ThumbprintSHA1 returns the thumbprint of the given cert in the same format used by the SDK and Client.SetThumbprint.
+func ThumbprintSHA1(cert *x509.Certificate) string {
+	sum := sha1.Sum(cert.Raw)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
+
+// SetThumbprint configures the client to accept the server certificate if its SHA1 thumbprint
+// matches the given thumbprint, rather than verifying the certificate chain.
+func (c *Client) SetThumbprint(thumbprint string) {
+	c.thumbprint = strings.ToUpper(thumbprint)
+
+	if c.thumbprint == "" {
+		c.t.DialTLS = nil
+		return
+	}
+
+	c.t.DialTLS = c.dialTLS
+}
+
+// Thumbprint returns the thumbprint set by SetThumbprint.
+func (c *Client) Thumbprint() string {
+	return c.thumbprint
+}
+
+func (c *Client) dialTLS(network string, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	config := &tls.Config{InsecureSkipVerify: true}
+	if c.t.TLSClientConfig != nil {
+		config.Certificates = c.t.TLSClientConfig.Certificates
+	}
+
+	conn, err := tls.DialWithDialer(dialer, network, addr, config)
+	if err != nil {
+		return nil, err
+	}
+
+	cert := conn.ConnectionState().PeerCertificates[0]
+	if thumbprint := ThumbprintSHA1(cert); thumbprint != c.thumbprint {
+		_ = conn.Close()
+		return nil, fmt.Errorf("host %q thumbprint %s does not match %s", addr, thumbprint, c.thumbprint)
+	}
+
+	return conn, nil
+}
+
 func (c *Client) URL() *url.URL {
 	urlCopy := *c.u
 	return &urlCopy
 }
 
 type marshaledClient struct {
-	Cookies  []*http.Cookie
-	URL      *url.URL
-	Insecure bool
+	Cookies    []*http.Cookie
+	URL        *url.URL
+	Insecure   bool
+	Thumbprint string `json:",omitempty"`
 }
 
 func (c *Client) MarshalJSON() ([]byte, error) {
 	m := marshaledClient{
-		Cookies:  c.Jar.Cookies(c.u),
-		URL:      c.u,
-		Insecure: c.k,
+		Cookies:    c.Jar.Cookies(c.u),
+		URL:        c.u,
+		Insecure:   c.k,
+		Thumbprint: c.thumbprint,
 	}
 
 	return json.Marshal(m)
@@ -207,6 +266,7 @@ func (c *Client) UnmarshalJSON(b []byte) error {
 
 	*c = *NewClient(m.URL, m.Insecure)
 	c.Jar.SetCookies(m.URL, m.Cookies)
+	c.SetThumbprint(m.Thumbprint)
 
 	return nil
 }
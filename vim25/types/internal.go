@@ -260,3 +260,32 @@ type ExecuteSoap struct {
 type ExecuteSoapResponse struct {
 	Returnval *ReflectManagedMethodExecuterSoapResult `xml:"urn:vim25 returnval"`
 }
+
+type QueryVirtualDiskInfo_Task struct {
+	This           ManagedObjectReference  `xml:"_this"`
+	Name           string                  `xml:"name"`
+	Datacenter     *ManagedObjectReference `xml:"datacenter,omitempty"`
+	IncludeParents bool                    `xml:"includeParents"`
+}
+
+type QueryVirtualDiskInfo_TaskResponse struct {
+	Returnval ManagedObjectReference `xml:"returnval"`
+}
+
+type VirtualDiskInfo struct {
+	Name     string `xml:"unit>name"`
+	DiskType string `xml:"diskType"`
+	Parent   string `xml:"parent,omitempty"`
+}
+
+func init() {
+	t["VirtualDiskInfo"] = reflect.TypeOf((*VirtualDiskInfo)(nil)).Elem()
+}
+
+type ArrayOfVirtualDiskInfo struct {
+	VirtualDiskInfo []VirtualDiskInfo `xml:"VirtualDiskInfo,omitempty"`
+}
+
+func init() {
+	t["ArrayOfVirtualDiskInfo"] = reflect.TypeOf((*ArrayOfVirtualDiskInfo)(nil)).Elem()
+}